@@ -20,7 +20,11 @@ func main() {
 	fmt.Println("🚀 启动 tisminSRETool 常驻调试模式...")
 
 	// 1) 初始化 Linux 采集器 + Runner
-	c := &collector.LinuxCollector{}
+	c := collector.NewLinuxCollector(model.CollectorConfig{
+		ProcRoot: os.Getenv("TISMIN_PROC_ROOT"),
+		SysRoot:  os.Getenv("TISMIN_SYS_ROOT"),
+		RootFS:   os.Getenv("TISMIN_ROOTFS"),
+	})
 	logger := log.New(os.Stdout, "[debug] ", log.LstdFlags|log.Lshortfile)
 	r := engine.NewRunner(c, 5*time.Second, logger)
 	alertCfg := model.AlertConfig{
@@ -36,10 +36,18 @@ func main() {
 	logger := setupLogger(cfg.App)
 
 	// 创建底层 Collector
-	linuxCollector := &collector.LinuxCollector{}
+	if cfg.App.LogLevel == "debug" {
+		cfg.Collector.Debug = true
+	}
+	cfg.Collector.StateDir = cfg.App.StateDir
+	if cfg.Collector.ProcessTopN <= 0 {
+		cfg.Collector.ProcessTopN = cfg.Diagnostic.ShowTopNList
+	}
+	linuxCollector := collector.NewLinuxCollector(cfg.Collector)
 
 	// 创建 Runner
 	runner := engine.NewRunner(linuxCollector, cfg.App.RefreshInterval, logger)
+	runner.SetStateDir(cfg.App.StateDir)
 
 	// 设置 Alert 层
 	if cfg.Alert.Enabled {
@@ -56,6 +64,7 @@ func main() {
 	var promExporter *exporter.PrometheusExporter
 	if cfg.Prometheus.Enabled {
 		promExporter = exporter.NewPrometheusExporter(runner)
+		promExporter.SetTextfileDir(cfg.Prometheus.TextfileDir)
 		go promExporter.StartMetricsCollector(ctx, cfg.App.RefreshInterval)
 	}
 
@@ -89,10 +98,22 @@ func loadConfig() *model.Config {
 
 	// 默认值
 	viper.SetDefault("app.refresh_interval", "5s")
+	viper.SetDefault("app.state_dir", "./data")
 	viper.SetDefault("http.listen", ":8080")
 	viper.SetDefault("http.timeout", "30s")
 	viper.SetDefault("prometheus.enabled", true)
 	viper.SetDefault("prometheus.path", "/metrics")
+	viper.SetDefault("collector.proc_root", "/proc")
+	viper.SetDefault("collector.sys_root", "/sys")
+	viper.SetDefault("collector.rootfs", "/")
+	viper.SetDefault("collector.statfs_timeout", "2s")
+	viper.SetDefault("collector.fd.enabled", true)
+	viper.SetDefault("collector.fd.growth_window", "10m")
+	viper.SetDefault("collector.fd.growth_min", 100)
+	viper.SetDefault("collector.cgroup.enabled", true)
+	viper.SetDefault("collector.cgroup.max_depth", 2)
+	viper.SetDefault("collector.kmsg.enabled", false)
+	viper.SetDefault("collector.kmsg.path", "/dev/kmsg")
 
 	if err := viper.ReadInConfig(); err != nil {
 		log.Printf("warning: config file not found, using defaults: %v", err)
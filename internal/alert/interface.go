@@ -9,7 +9,7 @@ import (
 // Alert 告警信息结构体
 type Alert struct {
 	Level     AlertLevel    // 告警级别：info/warn/error
-	Category  AlertCategory // 告警类别：cpu/memory/disk/network/inodes/tcp
+	Category  AlertCategory // 告警类别：cpu/memory/disk/network/inodes/tcp/pressure/mount/limits/hardware/host/log
 	Metric    string        // 指标名称
 	Message   string        // 告警消息
 	Value     float64       // 当前值
@@ -32,12 +32,18 @@ const (
 type AlertCategory string
 
 const (
-	CategoryCPU     AlertCategory = "cpu"
-	CategoryMemory  AlertCategory = "memory"
-	CategoryDisk    AlertCategory = "disk"
-	CategoryNetwork AlertCategory = "network"
-	CategoryInodes  AlertCategory = "inodes"
-	CategoryTCP     AlertCategory = "tcp"
+	CategoryCPU      AlertCategory = "cpu"
+	CategoryMemory   AlertCategory = "memory"
+	CategoryDisk     AlertCategory = "disk"
+	CategoryNetwork  AlertCategory = "network"
+	CategoryInodes   AlertCategory = "inodes"
+	CategoryTCP      AlertCategory = "tcp"
+	CategoryPressure AlertCategory = "pressure"
+	CategoryMount    AlertCategory = "mount"
+	CategoryLimits   AlertCategory = "limits"
+	CategoryHardware AlertCategory = "hardware"
+	CategoryHost     AlertCategory = "host"
+	CategoryLog      AlertCategory = "log"
 )
 
 type AlertChecker interface {
@@ -3,9 +3,14 @@ package alert
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"tisminSRETool/internal/model"
 )
 
+// 计算重传率所需的最低发送速率（segs/s）
+const minRetransOutSegsRate = 10
+
 type RuleChecker struct {
 	config model.AlertConfig
 }
@@ -33,6 +38,14 @@ func (r *RuleChecker) Check(ctx context.Context, m *model.Metrics) ([]Alert, err
 		r.checkDisk,
 		r.checkNet,
 		r.checkInodes,
+		r.checkMount,
+		r.checkNFS,
+		r.checkTCP,
+		r.checkPSI,
+		r.checkLimits,
+		r.checkSensors,
+		r.checkMD,
+		r.checkLogs,
 	}
 
 	for _, check := range checkers {
@@ -57,6 +70,28 @@ func (r *RuleChecker) checkCPU(m *model.Metrics) []Alert {
 			Unit:      "%",
 		})
 	}
+	if r.config.CPUIOWaitThreshold > 0 && m.CPU.Modes.IOWait > r.config.CPUIOWaitThreshold {
+		alerts = append(alerts, Alert{
+			Level:     LevelWarn,
+			Category:  CategoryCPU,
+			Metric:    "iowait_percent",
+			Message:   fmt.Sprintf("CPU iowait %.1f%% exceeds threshold %.1f%%", m.CPU.Modes.IOWait, r.config.CPUIOWaitThreshold),
+			Value:     m.CPU.Modes.IOWait,
+			Threshold: r.config.CPUIOWaitThreshold,
+			Unit:      "%",
+		})
+	}
+	if r.config.CPUStealThreshold > 0 && m.CPU.Modes.Steal > r.config.CPUStealThreshold {
+		alerts = append(alerts, Alert{
+			Level:     LevelWarn,
+			Category:  CategoryCPU,
+			Metric:    "steal_percent",
+			Message:   fmt.Sprintf("CPU steal %.1f%% exceeds threshold %.1f%%", m.CPU.Modes.Steal, r.config.CPUStealThreshold),
+			Value:     m.CPU.Modes.Steal,
+			Threshold: r.config.CPUStealThreshold,
+			Unit:      "%",
+		})
+	}
 	return alerts
 }
 
@@ -73,11 +108,49 @@ func (r *RuleChecker) checkMem(m *model.Metrics) []Alert {
 			Unit:      "%",
 		})
 	}
+
+	rates := m.VM.Rates
+	if r.config.SwapInRateThreshold > 0 && rates.PswpIn > r.config.SwapInRateThreshold {
+		alerts = append(alerts, Alert{
+			Level:     LevelError,
+			Category:  CategoryMemory,
+			Metric:    "swap_in_rate",
+			Message:   fmt.Sprintf("Swap-in rate %.1f pages/s (swap-out %.1f pages/s)", rates.PswpIn, rates.PswpOut),
+			Value:     rates.PswpIn,
+			Threshold: r.config.SwapInRateThreshold,
+			Unit:      "pages/s",
+		})
+	}
+	if r.config.MajorFaultRateThreshold > 0 && rates.PgMajFault > r.config.MajorFaultRateThreshold {
+		alerts = append(alerts, Alert{
+			Level:     LevelWarn,
+			Category:  CategoryMemory,
+			Metric:    "major_fault_rate",
+			Message:   fmt.Sprintf("Major page fault rate %.1f/s", rates.PgMajFault),
+			Value:     rates.PgMajFault,
+			Threshold: r.config.MajorFaultRateThreshold,
+			Unit:      "/s",
+		})
+	}
+	// 采集间隔内发生过 OOM kill 即告警；内核日志监听正常时已按事件告警，不再重复
+	if rates.OOMKill > 0 && !m.Kernel.Active {
+		alerts = append(alerts, Alert{
+			Level:     LevelError,
+			Category:  CategoryMemory,
+			Metric:    "oom_kill",
+			Message:   fmt.Sprintf("OOM killer invoked, total kills since boot %d", m.VM.OOMKill),
+			Value:     rates.OOMKill,
+			Threshold: 0,
+			Unit:      "/s",
+		})
+	}
 	return alerts
 }
 
 func (r *RuleChecker) checkDisk(m *model.Metrics) []Alert {
 	var alerts []Alert
+	// 同一设备的多个挂载点共享 IO 统计，await/util 每个设备只告警一次
+	ioSeen := make(map[string]bool)
 	for _, disk := range m.Disk {
 		if disk.UsedPercent > r.config.DiskThreshold {
 			alerts = append(alerts, Alert{
@@ -90,12 +163,16 @@ func (r *RuleChecker) checkDisk(m *model.Metrics) []Alert {
 				Unit:      "%",
 			})
 		}
+		if disk.Device == "" || ioSeen[disk.Device] {
+			continue
+		}
+		ioSeen[disk.Device] = true
 		if disk.Await > r.config.DiskAwaitThreshold && r.config.DiskAwaitThreshold > 0 {
 			alerts = append(alerts, Alert{
 				Level:     LevelWarn,
 				Category:  CategoryDisk,
 				Metric:    "await",
-				Message:   fmt.Sprintf("Disk %s await %.1fms exceeds threshold %.1fms", disk.MountPoint, disk.Await, r.config.DiskAwaitThreshold),
+				Message:   fmt.Sprintf("Disk %s await %.1fms exceeds threshold %.1fms", disk.Device, disk.Await, r.config.DiskAwaitThreshold),
 				Value:     disk.Await,
 				Threshold: r.config.DiskAwaitThreshold,
 				Unit:      "ms",
@@ -106,7 +183,7 @@ func (r *RuleChecker) checkDisk(m *model.Metrics) []Alert {
 				Level:     LevelWarn,
 				Category:  CategoryDisk,
 				Metric:    "util",
-				Message:   fmt.Sprintf("Disk %s util %.1f%% exceeds threshold %.1f%%", disk.MountPoint, disk.Util, r.config.DiskUtilThreshold),
+				Message:   fmt.Sprintf("Disk %s util %.1f%% exceeds threshold %.1f%%", disk.Device, disk.Util, r.config.DiskUtilThreshold),
 				Value:     disk.Util,
 				Threshold: r.config.DiskUtilThreshold,
 				Unit:      "%",
@@ -116,6 +193,24 @@ func (r *RuleChecker) checkDisk(m *model.Metrics) []Alert {
 	return alerts
 }
 
+func (r *RuleChecker) checkMount(m *model.Metrics) []Alert {
+	var alerts []Alert
+	for _, disk := range m.Disk {
+		if disk.Status != model.DiskStatusStale {
+			continue
+		}
+		alerts = append(alerts, Alert{
+			Level:    LevelError,
+			Category: CategoryMount,
+			Metric:   "mount_stale",
+			Message:  fmt.Sprintf("Mount %s (%s %s) is unresponsive, statfs timed out", disk.MountPoint, disk.FSType, disk.Source),
+			Value:    1,
+			Host:     m.Host,
+		})
+	}
+	return alerts
+}
+
 func (r *RuleChecker) checkInodes(m *model.Metrics) []Alert {
 	var alerts []Alert
 	for _, disk := range m.Disk {
@@ -156,6 +251,367 @@ func (r *RuleChecker) checkNet(m *model.Metrics) []Alert {
 				})
 			}
 		}
+
+		// 带宽使用率：仅对协商速率已知的网卡生效，取收发中较高的一侧
+		if r.config.NetworkBandwidthThreshold > 0 && net.SpeedMbps > 0 {
+			direction, util := "receive", net.RxUtilization
+			if net.TxUtilization > util {
+				direction, util = "transmit", net.TxUtilization
+			}
+			if util > r.config.NetworkBandwidthThreshold {
+				alerts = append(alerts, Alert{
+					Level:     LevelWarn,
+					Category:  CategoryNetwork,
+					Metric:    "network_bandwidth_utilization",
+					Message:   fmt.Sprintf("Network interface %s %s utilization %.2f%% of %d Mb/s exceeds threshold %.2f%%", net.Name, direction, util, net.SpeedMbps, r.config.NetworkBandwidthThreshold),
+					Value:     util,
+					Threshold: r.config.NetworkBandwidthThreshold,
+					Unit:      "%",
+					Host:      m.Host,
+				})
+			}
+		}
 	}
 	return alerts
 }
+
+func (r *RuleChecker) checkTCP(m *model.Metrics) []Alert {
+	var alerts []Alert
+	timeWait := m.TCP.States["TIME_WAIT"]
+	if r.config.TCPTimeWaitThreshold > 0 && timeWait > r.config.TCPTimeWaitThreshold {
+		alerts = append(alerts, Alert{
+			Level:     LevelWarn,
+			Category:  CategoryTCP,
+			Metric:    "tcp_time_wait",
+			Message:   fmt.Sprintf("TCP TIME_WAIT connections %d exceeds threshold %d", timeWait, r.config.TCPTimeWaitThreshold),
+			Value:     float64(timeWait),
+			Threshold: float64(r.config.TCPTimeWaitThreshold),
+			Unit:      "conn",
+			Host:      m.Host,
+		})
+	}
+	closeWait := m.TCP.States["CLOSE_WAIT"]
+	if r.config.TCPCLOSEWaitThreshold > 0 && closeWait > r.config.TCPCLOSEWaitThreshold {
+		alerts = append(alerts, Alert{
+			Level:     LevelError,
+			Category:  CategoryTCP,
+			Metric:    "tcp_close_wait",
+			Message:   fmt.Sprintf("TCP CLOSE_WAIT connections %d exceeds threshold %d", closeWait, r.config.TCPCLOSEWaitThreshold),
+			Value:     float64(closeWait),
+			Threshold: float64(r.config.TCPCLOSEWaitThreshold),
+			Unit:      "conn",
+			Host:      m.Host,
+		})
+	}
+	if r.config.TotalTCPThreshold > 0 && m.TCP.Total > r.config.TotalTCPThreshold {
+		alerts = append(alerts, Alert{
+			Level:     LevelWarn,
+			Category:  CategoryTCP,
+			Metric:    "tcp_total",
+			Message:   fmt.Sprintf("TCP connections %d exceeds threshold %d", m.TCP.Total, r.config.TotalTCPThreshold),
+			Value:     float64(m.TCP.Total),
+			Threshold: float64(r.config.TotalTCPThreshold),
+			Unit:      "conn",
+			Host:      m.Host,
+		})
+	}
+
+	// 以下规则依赖 engine 计算的速率，首轮没有速率时跳过
+	if m.Proto.Rates == nil {
+		return alerts
+	}
+	// 发送量过低时少量重传就会让比例失真，不做判断
+	outSegs := m.Proto.Rates["Tcp.OutSegs"]
+	if r.config.TCPRetransRatioThreshold > 0 && outSegs >= minRetransOutSegsRate && m.Proto.TCPRetransRatio > r.config.TCPRetransRatioThreshold {
+		alerts = append(alerts, Alert{
+			Level:     LevelWarn,
+			Category:  CategoryTCP,
+			Metric:    "tcp_retrans_ratio",
+			Message:   fmt.Sprintf("TCP retransmit ratio %.2f%% (%.1f/s of %.1f segs/s) exceeds threshold %.2f%%", m.Proto.TCPRetransRatio, m.Proto.Rates["Tcp.RetransSegs"], outSegs, r.config.TCPRetransRatioThreshold),
+			Value:     m.Proto.TCPRetransRatio,
+			Threshold: r.config.TCPRetransRatioThreshold,
+			Unit:      "%",
+			Host:      m.Host,
+		})
+	}
+	listenDrops := m.Proto.Rates["TcpExt.ListenDrops"]
+	if r.config.TCPListenDropsThreshold > 0 && listenDrops > r.config.TCPListenDropsThreshold {
+		alerts = append(alerts, Alert{
+			Level:     LevelWarn,
+			Category:  CategoryTCP,
+			Metric:    "tcp_listen_drops",
+			Message:   fmt.Sprintf("TCP listen drops %.2f/s (overflows %.2f/s) exceeds threshold %.2f/s", listenDrops, m.Proto.Rates["TcpExt.ListenOverflows"], r.config.TCPListenDropsThreshold),
+			Value:     listenDrops,
+			Threshold: r.config.TCPListenDropsThreshold,
+			Unit:      "/s",
+			Host:      m.Host,
+		})
+	}
+	return alerts
+}
+
+func (r *RuleChecker) checkPSI(m *model.Metrics) []Alert {
+	if !m.PSI.Available {
+		return nil
+	}
+	var alerts []Alert
+	rules := []struct {
+		metric    string
+		value     float64
+		threshold float64
+	}{
+		{"cpu_some", m.PSI.CPU.Some.Avg60, r.config.PSICPUSomeThreshold},
+		{"memory_some", m.PSI.Memory.Some.Avg60, r.config.PSIMemorySomeThreshold},
+		{"memory_full", m.PSI.Memory.Full.Avg60, r.config.PSIMemoryFullThreshold},
+		{"io_some", m.PSI.IO.Some.Avg60, r.config.PSIIOSomeThreshold},
+		{"io_full", m.PSI.IO.Full.Avg60, r.config.PSIIOFullThreshold},
+	}
+	for _, rule := range rules {
+		if rule.threshold <= 0 || rule.value <= rule.threshold {
+			continue
+		}
+		alerts = append(alerts, Alert{
+			Level:     LevelWarn,
+			Category:  CategoryPressure,
+			Metric:    "psi_" + rule.metric + "_avg60",
+			Message:   fmt.Sprintf("PSI %s avg60 %.2f%% exceeds threshold %.2f%%", rule.metric, rule.value, rule.threshold),
+			Value:     rule.value,
+			Threshold: rule.threshold,
+			Unit:      "%",
+			Host:      m.Host,
+		})
+	}
+	return alerts
+}
+
+func (r *RuleChecker) checkLimits(m *model.Metrics) []Alert {
+	var alerts []Alert
+	l := m.Limits
+	if r.config.FileHandlesThreshold > 0 && l.FileHandlesUsedPercent > r.config.FileHandlesThreshold {
+		alerts = append(alerts, Alert{
+			Level:     LevelError,
+			Category:  CategoryLimits,
+			Metric:    "file_handles_used_percent",
+			Message:   fmt.Sprintf("File handles %d/%d (%.1f%%) exceeds threshold %.1f%%", l.FileHandlesAllocated, l.FileHandlesMax, l.FileHandlesUsedPercent, r.config.FileHandlesThreshold),
+			Value:     l.FileHandlesUsedPercent,
+			Threshold: r.config.FileHandlesThreshold,
+			Unit:      "%",
+			Host:      m.Host,
+		})
+	}
+
+	// 线程数同时受 pid_max 与 threads-max 约束，取更接近上限的一项
+	metric, limit, used := "pid_used_percent", l.PIDMax, l.PIDUsedPercent
+	if l.ThreadsUsedPercent > used {
+		metric, limit, used = "threads_used_percent", l.ThreadsMax, l.ThreadsUsedPercent
+	}
+	if r.config.PIDThreshold > 0 && used > r.config.PIDThreshold {
+		alerts = append(alerts, Alert{
+			Level:     LevelError,
+			Category:  CategoryLimits,
+			Metric:    metric,
+			Message:   fmt.Sprintf("Tasks %d/%d (%.1f%%) exceeds threshold %.1f%%", l.TasksTotal, limit, used, r.config.PIDThreshold),
+			Value:     used,
+			Threshold: r.config.PIDThreshold,
+			Unit:      "%",
+			Host:      m.Host,
+		})
+	}
+
+	for _, p := range m.ProcsByFD {
+		if r.config.ProcessFDThreshold > 0 && p.FDUsedPercent > r.config.ProcessFDThreshold {
+			alerts = append(alerts, Alert{
+				Level:     LevelError,
+				Category:  CategoryLimits,
+				Metric:    "process_fd_used_percent",
+				Message:   fmt.Sprintf("Process %s (pid %d) has %d/%d open fds (%.1f%%)", p.Name, p.PID, p.FDs, p.FDSoftLimit, p.FDUsedPercent),
+				Value:     p.FDUsedPercent,
+				Threshold: r.config.ProcessFDThreshold,
+				Unit:      "%",
+				Host:      m.Host,
+			})
+		}
+		// 持续增长期间每轮都会判定为增长，只在开始增长时告警一次
+		if p.FDGrowthStart {
+			alerts = append(alerts, Alert{
+				Level:    LevelWarn,
+				Category: CategoryLimits,
+				Metric:   "process_fd_growth",
+				Message:  fmt.Sprintf("Process %s (pid %d) fds grew by %d to %d without decreasing, possible fd leak (sockets %d)", p.Name, p.PID, p.FDGrowth, p.FDs, p.Sockets),
+				Value:    float64(p.FDGrowth),
+				Unit:     "fds",
+				Host:     m.Host,
+			})
+		}
+	}
+	return alerts
+}
+
+// checkSensors 温度达到内核报告的临界阈值时告警，阈值来自硬件本身，无需配置
+func (r *RuleChecker) checkSensors(m *model.Metrics) []Alert {
+	var alerts []Alert
+	for _, s := range m.Sensors {
+		if s.Type != model.SensorTemperature || s.Crit <= 0 || s.Value < s.Crit {
+			continue
+		}
+		name := s.Chip + "/" + s.Sensor
+		if s.Label != "" {
+			name += " (" + s.Label + ")"
+		}
+		alerts = append(alerts, Alert{
+			Level:     LevelError,
+			Category:  CategoryHardware,
+			Metric:    "sensor_temperature",
+			Message:   fmt.Sprintf("Sensor %s on %s at %.1f°C reached critical threshold %.1f°C", name, s.Device, s.Value, s.Crit),
+			Value:     s.Value,
+			Threshold: s.Crit,
+			Unit:      "°C",
+			Host:      m.Host,
+		})
+	}
+	return alerts
+}
+
+func (r *RuleChecker) checkMD(m *model.Metrics) []Alert {
+	var alerts []Alert
+	for _, md := range m.MD {
+		// 未能组装启动的阵列没有 level 与成员数信息，单独告警
+		if strings.HasPrefix(md.State, "inactive") {
+			alerts = append(alerts, Alert{
+				Level:    LevelError,
+				Category: CategoryDisk,
+				Metric:   "md_inactive",
+				Message:  fmt.Sprintf("RAID array %s is inactive with %d member(s)", md.Name, len(md.Members)),
+				Unit:     "disks",
+				Host:     m.Host,
+			})
+			continue
+		}
+		if !md.Degraded {
+			continue
+		}
+		var failed []string
+		for _, member := range md.Members {
+			if member.State == model.MDMemberFailed {
+				failed = append(failed, member.Name)
+			}
+		}
+		msg := fmt.Sprintf("RAID array %s (%s) degraded, %d/%d disks active", md.Name, md.Level, md.DisksActive, md.DisksTotal)
+		if len(failed) > 0 {
+			msg += fmt.Sprintf(", failed: %s", strings.Join(failed, ","))
+		}
+		if md.SyncAction != "" {
+			msg += fmt.Sprintf(", %s %.1f%%", md.SyncAction, md.SyncPercent)
+		}
+		alerts = append(alerts, Alert{
+			Level:     LevelError,
+			Category:  CategoryDisk,
+			Metric:    "md_degraded",
+			Message:   msg,
+			Value:     float64(max(md.DisksTotal-md.DisksActive, md.Failed)),
+			Threshold: 0,
+			Unit:      "disks",
+			Host:      m.Host,
+		})
+	}
+	return alerts
+}
+
+func (r *RuleChecker) checkNFS(m *model.Metrics) []Alert {
+	var alerts []Alert
+	for _, mount := range m.NFS {
+		for _, op := range mount.Ops {
+			if r.config.NFSRetransThreshold > 0 && op.RetransRate > r.config.NFSRetransThreshold {
+				alerts = append(alerts, Alert{
+					Level:     LevelWarn,
+					Category:  CategoryMount,
+					Metric:    "nfs_retrans_rate",
+					Message:   fmt.Sprintf("NFS %s on %s: %s retransmits %.2f/s (%.2f ops/s)", mount.Export, mount.MountPoint, op.Op, op.RetransRate, op.OpsRate),
+					Value:     op.RetransRate,
+					Threshold: r.config.NFSRetransThreshold,
+					Unit:      "/s",
+					Host:      m.Host,
+				})
+			}
+			if r.config.NFSLatencyThreshold > 0 && op.AvgExecMs > r.config.NFSLatencyThreshold {
+				alerts = append(alerts, Alert{
+					Level:     LevelWarn,
+					Category:  CategoryMount,
+					Metric:    "nfs_op_latency",
+					Message:   fmt.Sprintf("NFS %s on %s: %s avg execute %.1fms (rtt %.1fms, %.2f ops/s)", mount.Export, mount.MountPoint, op.Op, op.AvgExecMs, op.AvgRTTMs, op.OpsRate),
+					Value:     op.AvgExecMs,
+					Threshold: r.config.NFSLatencyThreshold,
+					Unit:      "ms",
+					Host:      m.Host,
+				})
+			}
+		}
+	}
+	return alerts
+}
+
+func (r *RuleChecker) checkLogs(m *model.Metrics) []Alert {
+	var alerts []Alert
+	for _, l := range m.Logs {
+		if l.Threshold <= 0 || l.Rate <= l.Threshold {
+			continue
+		}
+		level := LevelWarn
+		if AlertLevel(l.Level) == LevelError {
+			level = LevelError
+		}
+		var labels []string
+		for name, v := range l.Labels {
+			labels = append(labels, name+"="+v)
+		}
+		sort.Strings(labels)
+		alerts = append(alerts, Alert{
+			Level:     level,
+			Category:  CategoryLog,
+			Metric:    "log_match_rate",
+			Message:   fmt.Sprintf("Log rule %s {%s} matched %.1f lines/min, last: %s", l.Rule, strings.Join(labels, ","), l.Rate, l.LastLine),
+			Value:     l.Rate,
+			Threshold: l.Threshold,
+			Unit:      "/min",
+			Host:      m.Host,
+		})
+	}
+	return alerts
+}
+
+// KernelEventAlert 将内核日志事件转换为告警，事件发生即告警，不经过阈值判断
+func KernelEventAlert(ev model.KernelEvent) Alert {
+	a := Alert{
+		Level:     LevelError,
+		Category:  CategoryHost,
+		Metric:    "kernel_" + ev.Type,
+		Value:     1,
+		Unit:      "events",
+		Timestamp: ev.Timestamp,
+	}
+	switch ev.Type {
+	case model.KernelEventOOMKill:
+		a.Category = CategoryMemory
+		a.Message = fmt.Sprintf("OOM killer killed process %s (pid %d): %s", ev.Process, ev.PID, ev.Message)
+	case model.KernelEventHungTask:
+		a.Level = LevelWarn
+		a.Message = fmt.Sprintf("Task %s (pid %d) hung: %s", ev.Process, ev.PID, ev.Message)
+	case model.KernelEventFSError:
+		a.Category = CategoryDisk
+		a.Message = fmt.Sprintf("Filesystem error on %s: %s", ev.Device, ev.Message)
+	case model.KernelEventIOError:
+		a.Category = CategoryDisk
+		a.Message = fmt.Sprintf("I/O error on %s: %s", ev.Device, ev.Message)
+	case model.KernelEventLinkDown:
+		a.Level = LevelWarn
+		a.Category = CategoryNetwork
+		a.Message = fmt.Sprintf("Network link %s down: %s", ev.Device, ev.Message)
+	case model.KernelEventLinkUp:
+		a.Level = LevelInfo
+		a.Category = CategoryNetwork
+		a.Message = fmt.Sprintf("Network link %s up: %s", ev.Device, ev.Message)
+	default:
+		a.Message = fmt.Sprintf("Kernel event %s: %s", ev.Type, ev.Message)
+	}
+	return a
+}
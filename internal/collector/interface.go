@@ -8,3 +8,8 @@ import (
 type Collector interface {
 	Collect(ctx context.Context) (*model.Metrics, *model.CollectErrors)
 }
+
+// EventSource 以流式方式产生事件的采集器（如内核日志），事件不等待采集周期
+type EventSource interface {
+	Events(ctx context.Context) <-chan model.KernelEvent
+}
@@ -0,0 +1,203 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"tisminSRETool/pkg/utils"
+)
+
+// mountEntry /proc/[pid]/mountinfo 中的一行
+type mountEntry struct {
+	DevNum     string // major:minor，btrfs 子卷、网络文件系统等为匿名设备号 0:N
+	Root       string // 挂载的是源文件系统中的哪个目录，bind 挂载或 btrfs 子卷时不为 /
+	MountPoint string
+	FSType     string
+	Source     string // 如 /dev/sda1、/dev/mapper/vg-lv、server:/export
+}
+
+// blockDevice /sys/class/block 下的一个块设备
+type blockDevice struct {
+	Name    string   // 内核设备名，如 sda1、nvme0n1p2、dm-0
+	DevNum  string   // major:minor
+	Parent  string   // 分区所在的整盘，如 sda1 -> sda；整盘为空
+	DMName  string   // device-mapper 名称，如 vg-lv（/dev/mapper/vg-lv）
+	Slaves  []string // 该设备所依赖的下层设备（dm/md）
+	Holders []string // 依赖该设备的上层设备（dm/md）
+}
+
+// blockTopology 块设备拓扑，用于把挂载源解析为 /proc/diskstats 中的设备名
+type blockTopology struct {
+	devices  map[string]*blockDevice
+	byDevNum map[string]string // major:minor -> 设备名
+	byDMName map[string]string // dm 名称 -> dm-N
+}
+
+// readMountInfo 读取挂载表
+// 优先读取 1 号进程的 mountinfo，容器中挂载宿主机 procfs 时得到的是宿主机的挂载点
+func (c *LinuxCollector) readMountInfo(ctx context.Context) ([]mountEntry, error) {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("1", "mountinfo"), 0, -1)
+	if err != nil {
+		lines, err = utils.ReadLinesOffsetNWithContext(ctx, c.procPath("self", "mountinfo"), 0, -1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 格式：36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+	var mounts []mountEntry
+	for _, line := range lines {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		fields := strings.Fields(line)
+		sep := -1
+		for i := 6; i < len(fields); i++ {
+			if fields[i] == "-" {
+				sep = i
+				break
+			}
+		}
+		if sep < 0 || sep+2 >= len(fields) {
+			continue
+		}
+		mounts = append(mounts, mountEntry{
+			DevNum:     fields[2],
+			Root:       unescapeMountPath(fields[3]),
+			MountPoint: unescapeMountPath(fields[4]),
+			FSType:     fields[sep+1],
+			Source:     unescapeMountPath(fields[sep+2]),
+		})
+	}
+	return mounts, nil
+}
+
+// unescapeMountPath 还原挂载表中的八进制转义，如 \040 -> 空格
+func unescapeMountPath(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// readBlockTopology 遍历 /sys/class/block 构建设备拓扑
+func (c *LinuxCollector) readBlockTopology() *blockTopology {
+	topo := &blockTopology{
+		devices:  make(map[string]*blockDevice),
+		byDevNum: make(map[string]string),
+		byDMName: make(map[string]string),
+	}
+	classDir := c.sysPath("class", "block")
+	entries, err := os.ReadDir(classDir)
+	if err != nil {
+		return topo
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		devDir := filepath.Join(classDir, name)
+		dev := &blockDevice{Name: name}
+
+		if data, err := os.ReadFile(filepath.Join(devDir, "dev")); err == nil {
+			dev.DevNum = strings.TrimSpace(string(data))
+			topo.byDevNum[dev.DevNum] = name
+		}
+		// 分区目录下存在 partition 文件，且在 sysfs 中位于整盘目录之下（.../block/sda/sda1）
+		if _, err := os.Stat(filepath.Join(devDir, "partition")); err == nil {
+			if target, err := filepath.EvalSymlinks(devDir); err == nil {
+				dev.Parent = filepath.Base(filepath.Dir(target))
+			}
+		}
+		if data, err := os.ReadFile(filepath.Join(devDir, "dm", "name")); err == nil {
+			dev.DMName = strings.TrimSpace(string(data))
+			topo.byDMName[dev.DMName] = name
+		}
+		dev.Slaves = readDirNames(filepath.Join(devDir, "slaves"))
+		dev.Holders = readDirNames(filepath.Join(devDir, "holders"))
+		topo.devices[name] = dev
+	}
+	return topo
+}
+
+func readDirNames(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+// resolve 将挂载项解析为块设备，依次尝试：
+//  1. mountinfo 中的设备号（最可靠，不受 /dev 下符号链接影响）
+//  2. /dev/mapper/<name> 对应的 dm 名称
+//  3. /dev/<name> 直接对应内核设备名
+//  4. 解析 /dev/disk/by-uuid 等符号链接
+func (t *blockTopology) resolve(m mountEntry, rootPath func(string) string) *blockDevice {
+	if name, ok := t.byDevNum[m.DevNum]; ok && !strings.HasPrefix(m.DevNum, "0:") {
+		return t.devices[name]
+	}
+	if !strings.HasPrefix(m.Source, "/dev/") {
+		return nil
+	}
+	if dmName, ok := strings.CutPrefix(m.Source, "/dev/mapper/"); ok {
+		if name, ok := t.byDMName[dmName]; ok {
+			return t.devices[name]
+		}
+	}
+	if dev, ok := t.devices[strings.TrimPrefix(m.Source, "/dev/")]; ok {
+		return dev
+	}
+	if target, err := filepath.EvalSymlinks(rootPath(m.Source)); err == nil {
+		if dev, ok := t.devices[filepath.Base(target)]; ok {
+			return dev
+		}
+	}
+	return nil
+}
+
+// parentDisks 返回设备最终所在的物理整盘
+// 分区返回其整盘；dm/md 沿 slaves 向下递归，可能对应多块盘（条带化 LVM、RAID）
+func (t *blockTopology) parentDisks(dev *blockDevice) []string {
+	seen := make(map[string]bool)
+	var disks []string
+	var walk func(d *blockDevice, depth int)
+	walk = func(d *blockDevice, depth int) {
+		if d == nil || depth > 8 {
+			return
+		}
+		if len(d.Slaves) > 0 {
+			for _, slave := range d.Slaves {
+				walk(t.devices[slave], depth+1)
+			}
+			return
+		}
+		disk := d.Name
+		if d.Parent != "" {
+			disk = d.Parent
+		}
+		if !seen[disk] {
+			seen[disk] = true
+			disks = append(disks, disk)
+		}
+	}
+	walk(dev, 0)
+	sort.Strings(disks)
+	return disks
+}
@@ -0,0 +1,204 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"tisminSRETool/internal/model"
+	"tisminSRETool/pkg/utils"
+)
+
+const defaultCgroupMaxDepth = 2
+
+// cgroupState 保存上一轮各 cgroup 的 CPU 累计用量，用于计算 CPU 使用率
+type cgroupState struct {
+	mu     sync.Mutex
+	prev   map[string]uint64
+	prevAt time.Time
+}
+
+// CollectCgroups 遍历 cgroup v2 层级，读取每个 cgroup 的 CPU/内存/IO/PIDs 用量
+// 通过 max_depth 与 allowlist 控制输出的 cgroup 数量，避免 Prometheus 标签基数失控
+func (c *LinuxCollector) CollectCgroups(ctx context.Context) ([]model.CgroupStat, error) {
+	root := c.cgroupRoot()
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err != nil {
+		return nil, fmt.Errorf("cgroup v2 unified hierarchy not found at %s: %w", root, err)
+	}
+
+	maxDepth := c.cgroup.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultCgroupMaxDepth
+	}
+
+	var out []model.CgroupStat
+	var walk func(dir, rel string, depth int) error
+	walk = func(dir, rel string, depth int) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if c.cgroupAllowed(rel) {
+			if stat, ok := c.readCgroup(dir, rel); ok {
+				out = append(out, stat)
+			}
+		}
+		if depth >= maxDepth {
+			return nil
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			// cgroup 在遍历过程中被删除是正常现象
+			return nil
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			if err := walk(filepath.Join(dir, entry.Name()), path.Join(rel, entry.Name()), depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(root, "/", 0); err != nil {
+		return nil, err
+	}
+
+	c.cgroupUsage.fillCPUPercent(out, time.Now())
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+func (c *LinuxCollector) cgroupRoot() string {
+	if c.cgroup.Root != "" {
+		return c.cgroup.Root
+	}
+	return c.sysPath("fs", "cgroup")
+}
+
+// cgroupAllowed 判断 cgroup 路径是否命中 allowlist（glob），allowlist 为空时全部输出
+func (c *LinuxCollector) cgroupAllowed(rel string) bool {
+	if len(c.cgroup.Allowlist) == 0 {
+		return true
+	}
+	for _, pattern := range c.cgroup.Allowlist {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// readCgroup 读取单个 cgroup 的统计文件，未启用的控制器对应文件不存在，直接忽略
+// cpu.stat 与 memory.current 都读不到时说明 cgroup 已在遍历过程中被删除，返回 false
+func (c *LinuxCollector) readCgroup(dir, rel string) (model.CgroupStat, bool) {
+	stat := model.CgroupStat{Path: rel}
+
+	cpu := readKeyValueFile(filepath.Join(dir, "cpu.stat"))
+	stat.CPUUsageUsec = cpu["usage_usec"]
+	stat.CPUUserUsec = cpu["user_usec"]
+	stat.CPUSystemUsec = cpu["system_usec"]
+	stat.NrThrottled = cpu["nr_throttled"]
+	stat.ThrottledUsec = cpu["throttled_usec"]
+
+	var memErr error
+	stat.MemoryCurrent, memErr = readUintFile(filepath.Join(dir, "memory.current"))
+	// memory.max 为 "max" 表示不限制，此时保持 0
+	stat.MemoryMax, _ = readUintFile(filepath.Join(dir, "memory.max"))
+	if stat.MemoryMax > 0 {
+		stat.MemoryUsedPercent = utils.Pct(stat.MemoryCurrent, stat.MemoryMax)
+	}
+	events := readKeyValueFile(filepath.Join(dir, "memory.events"))
+	stat.OOMEvents = events["oom"]
+	stat.OOMKills = events["oom_kill"]
+
+	stat.PidsCurrent, _ = readUintFile(filepath.Join(dir, "pids.current"))
+
+	// io.stat 每行一个设备：8:0 rbytes=1 wbytes=2 rios=3 wios=4 dbytes=0 dios=0
+	if lines, err := utils.ReadLines(filepath.Join(dir, "io.stat")); err == nil {
+		for _, line := range lines {
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			for _, kv := range fields[1:] {
+				key, value, ok := strings.Cut(kv, "=")
+				if !ok {
+					continue
+				}
+				v, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					continue
+				}
+				switch key {
+				case "rbytes":
+					stat.IOReadBytes += v
+				case "wbytes":
+					stat.IOWriteBytes += v
+				case "rios":
+					stat.IOReadOps += v
+				case "wios":
+					stat.IOWriteOps += v
+				}
+			}
+		}
+	}
+	return stat, cpu != nil || memErr == nil
+}
+
+func (s *cgroupState) fillCPUPercent(stats []model.CgroupStat, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := 0.0
+	if !s.prevAt.IsZero() {
+		elapsed = now.Sub(s.prevAt).Seconds()
+	}
+	cur := make(map[string]uint64, len(stats))
+	for i := range stats {
+		cur[stats[i].Path] = stats[i].CPUUsageUsec
+		prev, ok := s.prev[stats[i].Path]
+		if !ok || elapsed <= 0 {
+			continue
+		}
+		stats[i].CPUUsagePercent = float64(uint64Diff(stats[i].CPUUsageUsec, prev)) / 1e6 / elapsed * 100
+	}
+	s.prev = cur
+	s.prevAt = now
+}
+
+// readKeyValueFile 解析 "key value" 每行一对的文件（cpu.stat、memory.events 等），读取失败返回 nil
+func readKeyValueFile(filename string) map[string]uint64 {
+	lines, err := utils.ReadLines(filename)
+	if err != nil {
+		return nil
+	}
+	ret := make(map[string]uint64)
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		ret[fields[0]] = v
+	}
+	return ret
+}
+
+// readUintFile 读取只包含一个整数的文件
+func readUintFile(filename string) (uint64, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
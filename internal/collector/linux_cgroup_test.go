@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+	"tisminSRETool/internal/model"
+)
+
+// testdata/sys/fs/cgroup：user.slice 只剩空目录（模拟遍历时被删除），nested 超出默认遍历深度
+func TestCollectCgroups(t *testing.T) {
+	c := &LinuxCollector{sysRoot: "testdata/sys"}
+	got, err := c.CollectCgroups(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []model.CgroupStat{
+		{Path: "/", CPUUsageUsec: 9000000, CPUUserUsec: 6000000, CPUSystemUsec: 3000000},
+		{
+			Path: "/system.slice", CPUUsageUsec: 4000000, CPUUserUsec: 3000000, CPUSystemUsec: 1000000,
+			MemoryCurrent: 209715200, PidsCurrent: 120,
+		},
+		{
+			Path: "/system.slice/docker.service", CPUUsageUsec: 2500000, CPUUserUsec: 2000000, CPUSystemUsec: 500000,
+			NrThrottled: 5, ThrottledUsec: 200000,
+			MemoryCurrent: 52428800, MemoryMax: 104857600, MemoryUsedPercent: 50,
+			OOMEvents: 2, OOMKills: 1,
+			IOReadBytes: 5120, IOWriteBytes: 10240, IOReadOps: 4, IOWriteOps: 6,
+			PidsCurrent: 42,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectCgroups mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+
+	c = &LinuxCollector{sysRoot: "testdata/sys", cgroup: model.CgroupConfig{Allowlist: []string{"/system.slice/*.service"}}}
+	got, err = c.CollectCgroups(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Path != "/system.slice/docker.service" {
+		t.Errorf("CollectCgroups with allowlist = %+v; want only /system.slice/docker.service", got)
+	}
+}
+
+func TestCgroupCPUPercent(t *testing.T) {
+	var s cgroupState
+	now := time.Now()
+	s.fillCPUPercent([]model.CgroupStat{{Path: "/a", CPUUsageUsec: 1000000}}, now)
+
+	// 10 秒内用了 5 秒 CPU；新出现的 cgroup 没有上一轮数据，保持 0
+	stats := []model.CgroupStat{{Path: "/a", CPUUsageUsec: 6000000}, {Path: "/b", CPUUsageUsec: 1000000}}
+	s.fillCPUPercent(stats, now.Add(10*time.Second))
+	if !approxEqual(stats[0].CPUUsagePercent, 50) || stats[1].CPUUsagePercent != 0 {
+		t.Errorf("cpu percent = %v, %v; want 50, 0", stats[0].CPUUsagePercent, stats[1].CPUUsagePercent)
+	}
+}
@@ -4,16 +4,118 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 	"tisminSRETool/internal/model"
 )
 
-type LinuxCollector struct{}
+const (
+	defaultProcRoot = "/proc"
+	defaultSysRoot  = "/sys"
+	defaultRootFS   = "/"
+)
+
+// LinuxCollector 从 procfs/sysfs 采集指标。
+// 零值可直接使用，默认读取 /proc 与 /sys；容器中运行时可将宿主机的
+// /proc、/sys、/ 挂载到其他位置（如 /host/proc），或指向采集下来的快照目录。
+type LinuxCollector struct {
+	procRoot string
+	sysRoot  string
+	rootFS   string
+	debug    bool
+
+	cpu           cpuState
+	disk          diskState
+	statfs        statfsState
+	statfsTimeout time.Duration
+	diskFilter    *diskFilter
+
+	netFilter *netFilter
+
+	processTopN int
+	procs       processState
+	fd          model.FDConfig
+
+	cgroup      model.CgroupConfig
+	cgroupUsage cgroupState
+
+	hostInfo hostInfoState
+
+	kmsg *kmsgWatcher
+	logs *logTailer
+}
+
+var (
+	_ Collector   = (*LinuxCollector)(nil)
+	_ EventSource = (*LinuxCollector)(nil)
+)
 
-var _ Collector = (*LinuxCollector)(nil)
+func NewLinuxCollector(cfg model.CollectorConfig) *LinuxCollector {
+	return &LinuxCollector{
+		procRoot: cfg.ProcRoot,
+		sysRoot:  cfg.SysRoot,
+		rootFS:   cfg.RootFS,
+		debug:    cfg.Debug,
+
+		statfsTimeout: cfg.StatfsTimeout,
+		diskFilter:    newDiskFilter(cfg.Disk),
+
+		netFilter: newNetFilter(cfg.Net),
+
+		processTopN: cfg.ProcessTopN,
+		fd:          cfg.FD,
+
+		cgroup: cfg.Cgroup,
+
+		kmsg: newKmsgWatcher(cfg.Kmsg),
+		logs: newLogTailer(cfg.Logs, cfg.StateDir),
+	}
+}
+
+// procPath 拼接 procfs 下的路径，如 procPath("net", "dev") -> /proc/net/dev
+func (c *LinuxCollector) procPath(elem ...string) string {
+	root := c.procRoot
+	if root == "" {
+		root = defaultProcRoot
+	}
+	return filepath.Join(append([]string{root}, elem...)...)
+}
+
+// sysPath 拼接 sysfs 下的路径，如 sysPath("block") -> /sys/block
+func (c *LinuxCollector) sysPath(elem ...string) string {
+	root := c.sysRoot
+	if root == "" {
+		root = defaultSysRoot
+	}
+	return filepath.Join(append([]string{root}, elem...)...)
+}
+
+// rootPath 将宿主机上的绝对路径（如挂载点）映射到 rootFS 下
+func (c *LinuxCollector) rootPath(path string) string {
+	root := c.rootFS
+	if root == "" {
+		root = defaultRootFS
+	}
+	return filepath.Join(root, path)
+}
+
+// hostPath 是 rootPath 的逆操作，去掉 rootfs 前缀得到宿主机上的路径
+func (c *LinuxCollector) hostPath(path string) string {
+	root := c.rootFS
+	if root == "" {
+		root = defaultRootFS
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return path
+	}
+	return filepath.Join("/", rel)
+}
 
 func (c *LinuxCollector) Collect(ctx context.Context) (*model.Metrics, *model.CollectErrors) {
+
 	host := "localhost"
 	if h, err := os.Hostname(); err == nil && h != "" {
 		host = h
@@ -25,15 +127,25 @@ func (c *LinuxCollector) Collect(ctx context.Context) (*model.Metrics, *model.Co
 	}
 	errs := &model.CollectErrors{}
 
+	// 内核日志由后台监听持续读取，这里只取累计计数
+	if c.kmsg != nil {
+		stat, err := c.kmsg.snapshot()
+		stat.Active = err == nil
+		metrics.Kernel = stat
+		if err != nil {
+			errs.Kernel = append(errs.Kernel, err)
+		}
+	}
+
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	var errMu sync.Mutex
 
-	wg.Add(4)
+	wg.Add(16)
 
 	go func() {
 		defer wg.Done()
-		cpuStat, err := CollectCPUStat(ctx)
+		cpuStat, err := c.CollectCPUStat(ctx)
 		if err != nil {
 			errMu.Lock()
 			errs.CPU = append(errs.CPU, err)
@@ -47,7 +159,7 @@ func (c *LinuxCollector) Collect(ctx context.Context) (*model.Metrics, *model.Co
 
 	go func() {
 		defer wg.Done()
-		memStat, err := CollectMeminfo(ctx)
+		memStat, err := c.CollectMeminfo(ctx)
 		if err != nil {
 			errMu.Lock()
 			errs.Mem = append(errs.Mem, err)
@@ -67,7 +179,21 @@ func (c *LinuxCollector) Collect(ctx context.Context) (*model.Metrics, *model.Co
 
 	go func() {
 		defer wg.Done()
-		diskStat, err := CollectDisk(ctx)
+		vmStat, err := c.CollectVMStat(ctx)
+		if err != nil {
+			errMu.Lock()
+			errs.VM = append(errs.VM, err)
+			errMu.Unlock()
+			return
+		}
+		mu.Lock()
+		metrics.VM = vmStat
+		mu.Unlock()
+	}()
+
+	go func() {
+		defer wg.Done()
+		diskStat, err := c.CollectDisk(ctx)
 		if err != nil {
 			errMu.Lock()
 			errs.Disk = append(errs.Disk, err)
@@ -81,7 +207,7 @@ func (c *LinuxCollector) Collect(ctx context.Context) (*model.Metrics, *model.Co
 
 	go func() {
 		defer wg.Done()
-		netStat, err := CollectNetinfo(ctx)
+		netStat, err := c.CollectNetinfo(ctx)
 		if err != nil {
 			errMu.Lock()
 			errs.Net = append(errs.Net, err)
@@ -93,6 +219,197 @@ func (c *LinuxCollector) Collect(ctx context.Context) (*model.Metrics, *model.Co
 		mu.Unlock()
 	}()
 
+	go func() {
+		defer wg.Done()
+		byCPU, byMem, byFD, err := c.CollectProcesses(ctx)
+		if err != nil {
+			errMu.Lock()
+			errs.Proc = append(errs.Proc, err)
+			errMu.Unlock()
+			return
+		}
+		mu.Lock()
+		metrics.Procs = byCPU
+		metrics.ProcsByMem = byMem
+		metrics.ProcsByFD = byFD
+		mu.Unlock()
+	}()
+
+	go func() {
+		defer wg.Done()
+		tcpStat, err := c.CollectTCP(ctx)
+		if err != nil {
+			errMu.Lock()
+			errs.TCP = append(errs.TCP, err)
+			errMu.Unlock()
+			return
+		}
+		mu.Lock()
+		metrics.TCP = *tcpStat
+		mu.Unlock()
+	}()
+
+	go func() {
+		defer wg.Done()
+		protoStat, err := c.CollectProtoStats(ctx)
+		if err != nil {
+			errMu.Lock()
+			errs.Proto = append(errs.Proto, err)
+			errMu.Unlock()
+			return
+		}
+		mu.Lock()
+		metrics.Proto = protoStat
+		mu.Unlock()
+	}()
+
+	go func() {
+		defer wg.Done()
+		limits, err := c.CollectLimits(ctx)
+		if err != nil {
+			errMu.Lock()
+			errs.Limits = append(errs.Limits, err)
+			errMu.Unlock()
+			return
+		}
+		mu.Lock()
+		metrics.Limits = limits
+		mu.Unlock()
+	}()
+
+	go func() {
+		defer wg.Done()
+		info, err := c.CollectHostInfo(ctx)
+		if err != nil {
+			errMu.Lock()
+			errs.Info = append(errs.Info, err)
+			errMu.Unlock()
+			return
+		}
+		mu.Lock()
+		metrics.Info = info
+		mu.Unlock()
+	}()
+
+	go func() {
+		defer wg.Done()
+		freq, err := c.CollectCPUFreq()
+		if err != nil {
+			errMu.Lock()
+			errs.CPUFreq = append(errs.CPUFreq, err)
+			errMu.Unlock()
+			return
+		}
+		mu.Lock()
+		metrics.CPUFreq = freq
+		mu.Unlock()
+	}()
+
+	go func() {
+		defer wg.Done()
+		irq, err := c.CollectIRQ(ctx)
+		if err != nil {
+			errMu.Lock()
+			errs.IRQ = append(errs.IRQ, err)
+			errMu.Unlock()
+			return
+		}
+		mu.Lock()
+		metrics.IRQ = irq
+		mu.Unlock()
+	}()
+
+	go func() {
+		defer wg.Done()
+		nfs, err := c.CollectNFS(ctx)
+		if err != nil {
+			errMu.Lock()
+			errs.NFS = append(errs.NFS, err)
+			errMu.Unlock()
+			return
+		}
+		mu.Lock()
+		metrics.NFS = nfs
+		mu.Unlock()
+	}()
+
+	go func() {
+		defer wg.Done()
+		md, err := c.CollectMDStat(ctx)
+		if err != nil {
+			errMu.Lock()
+			errs.MD = append(errs.MD, err)
+			errMu.Unlock()
+			return
+		}
+		mu.Lock()
+		metrics.MD = md
+		mu.Unlock()
+	}()
+
+	go func() {
+		defer wg.Done()
+		sensors, err := c.CollectSensors()
+		if err != nil {
+			errMu.Lock()
+			errs.Sensors = append(errs.Sensors, err)
+			errMu.Unlock()
+			return
+		}
+		mu.Lock()
+		metrics.Sensors = sensors
+		mu.Unlock()
+	}()
+
+	go func() {
+		defer wg.Done()
+		psiStat, err := c.CollectPSI(ctx)
+		if err != nil {
+			errMu.Lock()
+			errs.PSI = append(errs.PSI, err)
+			errMu.Unlock()
+			return
+		}
+		mu.Lock()
+		metrics.PSI = psiStat
+		mu.Unlock()
+	}()
+
+	if c.logs != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			matches, files, err := c.CollectLogs(ctx)
+			if err != nil {
+				errMu.Lock()
+				errs.Logs = append(errs.Logs, err)
+				errMu.Unlock()
+			}
+			// 部分文件读取失败时仍输出其余文件的计数
+			mu.Lock()
+			metrics.Logs = matches
+			metrics.LogFiles = files
+			mu.Unlock()
+		}()
+	}
+
+	if c.cgroup.Enabled {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cgroups, err := c.CollectCgroups(ctx)
+			if err != nil {
+				errMu.Lock()
+				errs.Cgroup = append(errs.Cgroup, err)
+				errMu.Unlock()
+				return
+			}
+			mu.Lock()
+			metrics.Cgroups = cgroups
+			mu.Unlock()
+		}()
+	}
+
 	done := make(chan struct{})
 	go func() {
 		wg.Wait()
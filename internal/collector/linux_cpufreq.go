@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"tisminSRETool/internal/model"
+)
+
+// CollectCPUFreq 读取各 CPU 的 cpufreq 频率与 thermal_throttle 降频计数
+// 虚拟机通常没有 cpufreq 目录，此时返回空列表
+func (c *LinuxCollector) CollectCPUFreq() ([]model.CPUFreqStat, error) {
+	var ret []model.CPUFreqStat
+	for _, name := range readDirNames(c.sysPath("devices", "system", "cpu")) {
+		id, ok := strings.CutPrefix(name, "cpu")
+		if !ok {
+			continue
+		}
+		if _, err := strconv.Atoi(id); err != nil {
+			continue
+		}
+		dir := c.sysPath("devices", "system", "cpu", name)
+		freqDir := filepath.Join(dir, "cpufreq")
+		throttleDir := filepath.Join(dir, "thermal_throttle")
+
+		// sysfs 中频率单位为 kHz
+		s := model.CPUFreqStat{CPU: id}
+		cur, hasFreq := readSysfsFloat(filepath.Join(freqDir, "scaling_cur_freq"), 1)
+		if !hasFreq {
+			cur, hasFreq = readSysfsFloat(filepath.Join(freqDir, "cpuinfo_cur_freq"), 1)
+		}
+		s.CurHz = uint64(cur * 1000)
+		if v, ok := readSysfsFloat(filepath.Join(freqDir, "scaling_min_freq"), 1); ok {
+			s.MinHz = uint64(v * 1000)
+		}
+		if v, ok := readSysfsFloat(filepath.Join(freqDir, "scaling_max_freq"), 1); ok {
+			s.MaxHz = uint64(v * 1000)
+		}
+		s.Governor = readSysfsString(filepath.Join(freqDir, "scaling_governor"))
+
+		core, hasCore := readSysfsFloat(filepath.Join(throttleDir, "core_throttle_count"), 1)
+		pkg, hasPkg := readSysfsFloat(filepath.Join(throttleDir, "package_throttle_count"), 1)
+		s.CoreThrottleCount = uint64(core)
+		s.PackageThrottleCount = uint64(pkg)
+
+		if hasFreq || hasCore || hasPkg {
+			ret = append(ret, s)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		a, _ := strconv.Atoi(ret[i].CPU)
+		b, _ := strconv.Atoi(ret[j].CPU)
+		return a < b
+	})
+	return ret, nil
+}
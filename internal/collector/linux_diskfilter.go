@@ -0,0 +1,166 @@
+package collector
+
+import (
+	"log"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"tisminSRETool/internal/model"
+)
+
+// 默认排除的虚拟文件系统
+var defaultExcludeFSTypes = []string{
+	"tmpfs", "devtmpfs", "overlay", "aufs", "devpts", "sysfs", "proc",
+	"cgroup", "cgroup2", "securityfs", "pstore", "efivarfs", "bpf",
+	"tracefs", "hugetlbfs", "mqueue", "fusectl", "configfs", "debugfs", "selinuxfs",
+}
+
+// 默认排除的块设备
+var defaultExcludeDevices = []string{"loop*", "ram*"}
+
+// matcher 路径/设备名匹配规则：
+//   - "regex:" 前缀为正则表达式
+//   - 以 "/**" 结尾匹配该目录及其所有子路径
+//   - 其他按 glob 匹配（path.Match 语义，* 不跨越 /）
+type matcher struct {
+	raw    string
+	re     *regexp.Regexp
+	prefix string
+}
+
+func newMatchers(patterns []string) []matcher {
+	ms := make([]matcher, 0, len(patterns))
+	for _, p := range patterns {
+		switch {
+		case strings.HasPrefix(p, "regex:"):
+			re, err := regexp.Compile(strings.TrimPrefix(p, "regex:"))
+			if err != nil {
+				log.Printf("invalid disk filter pattern %q: %v", p, err)
+				continue
+			}
+			ms = append(ms, matcher{raw: p, re: re})
+		case strings.HasSuffix(p, "/**"):
+			ms = append(ms, matcher{raw: p, prefix: strings.TrimSuffix(p, "/**")})
+		default:
+			if _, err := path.Match(p, ""); err != nil {
+				log.Printf("invalid disk filter pattern %q: %v", p, err)
+				continue
+			}
+			ms = append(ms, matcher{raw: p})
+		}
+	}
+	return ms
+}
+
+func (m matcher) match(s string) bool {
+	switch {
+	case m.re != nil:
+		return m.re.MatchString(s)
+	case m.prefix != "":
+		return s == m.prefix || strings.HasPrefix(s, m.prefix+"/")
+	default:
+		ok, _ := path.Match(m.raw, s)
+		return ok
+	}
+}
+
+func matchAny(ms []matcher, s string) (string, bool) {
+	for _, m := range ms {
+		if m.match(s) {
+			return m.raw, true
+		}
+	}
+	return "", false
+}
+
+// diskFilter 磁盘采集过滤规则，同时作用于容量（挂载点）与 IO（块设备）采集
+// include 规则优先：命中任一 include 的挂载点一定会被采集，即使同时命中 exclude
+type diskFilter struct {
+	includeFSTypes map[string]bool
+	excludeFSTypes map[string]bool
+	includeMounts  []matcher
+	excludeMounts  []matcher
+	includeDevices []matcher
+	excludeDevices []matcher
+
+	mu           sync.Mutex
+	lastFiltered string
+}
+
+// defaultDiskFilter 零值 LinuxCollector（diskFilter 为 nil）使用的默认规则
+var defaultDiskFilter = newDiskFilter(model.DiskFilterConfig{})
+
+func newDiskFilter(cfg model.DiskFilterConfig) *diskFilter {
+	excludeFSTypes := cfg.ExcludeFSTypes
+	if len(excludeFSTypes) == 0 {
+		excludeFSTypes = defaultExcludeFSTypes
+	}
+	excludeDevices := cfg.ExcludeDevices
+	if len(excludeDevices) == 0 {
+		excludeDevices = defaultExcludeDevices
+	}
+	return &diskFilter{
+		includeFSTypes: toSet(cfg.IncludeFSTypes),
+		excludeFSTypes: toSet(excludeFSTypes),
+		includeMounts:  newMatchers(cfg.IncludeMountPoints),
+		excludeMounts:  newMatchers(cfg.ExcludeMountPoints),
+		includeDevices: newMatchers(cfg.IncludeDevices),
+		excludeDevices: newMatchers(excludeDevices),
+	}
+}
+
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
+
+// keepMount 判断挂载点是否采集；forced 表示命中 include 规则，reason 为被过滤的原因
+// nil 接收者按默认规则处理
+func (f *diskFilter) keepMount(m mountEntry, device string) (keep, forced bool, reason string) {
+	if f == nil {
+		f = defaultDiskFilter
+	}
+	if f.includeFSTypes[m.FSType] {
+		return true, true, ""
+	}
+	if _, ok := matchAny(f.includeMounts, m.MountPoint); ok {
+		return true, true, ""
+	}
+	if device != "" {
+		if _, ok := matchAny(f.includeDevices, device); ok {
+			return true, true, ""
+		}
+	}
+
+	if f.excludeFSTypes[m.FSType] {
+		return false, false, "fstype " + m.FSType
+	}
+	if p, ok := matchAny(f.excludeMounts, m.MountPoint); ok {
+		return false, false, "mount point " + p
+	}
+	if device != "" {
+		if p, ok := matchAny(f.excludeDevices, device); ok {
+			return false, false, "device " + p
+		}
+	}
+	return true, false, ""
+}
+
+// logFiltered 在 debug 模式下输出被过滤的挂载点，仅在列表变化时打印，避免每轮刷屏
+func (f *diskFilter) logFiltered(filtered []string) {
+	if f == nil {
+		f = defaultDiskFilter
+	}
+	joined := strings.Join(filtered, ", ")
+	f.mu.Lock()
+	changed := joined != f.lastFiltered
+	f.lastFiltered = joined
+	f.mu.Unlock()
+	if changed {
+		log.Printf("disk filter skipped %d mount(s): %s", len(filtered), joined)
+	}
+}
@@ -0,0 +1,238 @@
+package collector
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"tisminSRETool/internal/model"
+	"tisminSRETool/pkg/utils"
+)
+
+const (
+	defaultFDGrowthWindow = 10 * time.Minute
+	defaultFDGrowthMin    = 100
+
+	// limits 很少变化，未入选 TopN 的进程按此间隔重新读取，入选的进程每轮读取
+	nofileLimitTTL = 5 * time.Minute
+)
+
+type fdSample struct {
+	at    time.Time
+	count uint64
+}
+
+// fdHistory 单个进程在增长窗口内的 fd 数量采样
+type fdHistory struct {
+	startTime uint64 // 用于识别 PID 复用
+	samples   []fdSample
+	growing   bool // 上一轮是否判定为持续增长
+}
+
+// nofileLimit 缓存的 RLIMIT_NOFILE
+type nofileLimit struct {
+	startTime  uint64
+	soft, hard uint64
+	at         time.Time
+}
+
+// trackFDs 记录本轮各进程的 fd 数，返回增长窗口内的变化量、是否持续增长以及是否本轮刚开始持续增长
+// 持续增长：采样覆盖整个窗口、期间 fd 数从未下降，且净增长不低于 growthMin
+func (s *processState) trackFDs(samples map[int]procSample, now time.Time, window time.Duration, growthMin uint64) (growth map[int]int64, growing, started map[int]bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fdHistory == nil {
+		s.fdHistory = make(map[int]*fdHistory)
+	}
+	growth = make(map[int]int64)
+	growing = make(map[int]bool)
+	started = make(map[int]bool)
+	windowStart := now.Add(-window)
+
+	for pid, cur := range samples {
+		if !cur.fdKnown {
+			continue
+		}
+		h, ok := s.fdHistory[pid]
+		if !ok || h.startTime != cur.startTime {
+			h = &fdHistory{startTime: cur.startTime}
+			s.fdHistory[pid] = h
+		}
+		h.samples = append(h.samples, fdSample{at: now, count: cur.fds})
+		// 保留窗口起点之前最近的一个采样作为基线
+		for len(h.samples) >= 2 && !h.samples[1].at.After(windowStart) {
+			h.samples = h.samples[1:]
+		}
+
+		first := h.samples[0]
+		growth[pid] = int64(cur.fds) - int64(first.count)
+		g := !first.at.After(windowStart) && cur.fds >= first.count+growthMin && monotonicFDs(h.samples)
+		started[pid] = g && !h.growing
+		growing[pid] = g
+		h.growing = g
+	}
+
+	for pid := range s.fdHistory {
+		if cur, ok := samples[pid]; !ok || !cur.fdKnown {
+			delete(s.fdHistory, pid)
+		}
+	}
+	return growth, growing, started
+}
+
+func monotonicFDs(samples []fdSample) bool {
+	for i := 1; i < len(samples); i++ {
+		if samples[i].count < samples[i-1].count {
+			return false
+		}
+	}
+	return true
+}
+
+// cachedNOFILELimits 返回各进程的 RLIMIT_NOFILE，只读取新进程和缓存过期的进程，
+// 避免每轮为所有进程读取 /proc/[pid]/limits
+func (c *LinuxCollector) cachedNOFILELimits(samples map[int]procSample, now time.Time) map[int]nofileLimit {
+	c.procs.mu.Lock()
+	defer c.procs.mu.Unlock()
+
+	if c.procs.nofile == nil {
+		c.procs.nofile = make(map[int]nofileLimit)
+	}
+	for pid, cur := range samples {
+		if !cur.fdKnown {
+			continue
+		}
+		l, ok := c.procs.nofile[pid]
+		if ok && l.startTime == cur.startTime && now.Sub(l.at) < nofileLimitTTL {
+			continue
+		}
+		soft, hard, err := c.readNOFILELimit(pid)
+		if err != nil {
+			delete(c.procs.nofile, pid)
+			continue
+		}
+		c.procs.nofile[pid] = nofileLimit{startTime: cur.startTime, soft: soft, hard: hard, at: now}
+	}
+	for pid := range c.procs.nofile {
+		if cur, ok := samples[pid]; !ok || !cur.fdKnown {
+			delete(c.procs.nofile, pid)
+		}
+	}
+
+	ret := make(map[int]nofileLimit, len(c.procs.nofile))
+	for pid, l := range c.procs.nofile {
+		ret[pid] = l
+	}
+	return ret
+}
+
+// refreshNOFILELimit 重新读取入选进程的 limits，避免告警基于过期的缓存值
+func (c *LinuxCollector) refreshNOFILELimit(p *model.ProcStat) {
+	soft, hard, err := c.readNOFILELimit(p.PID)
+	if err != nil {
+		return
+	}
+	p.FDSoftLimit = soft
+	p.FDHardLimit = hard
+	p.FDUsedPercent = utils.Pct(p.FDs, soft)
+}
+
+// countFDs 统计 /proc/[pid]/fd 下的条目数，无权限读取时返回错误
+// 6.2+ 内核中该目录的 st_size 即为 fd 数，可以省去遍历目录
+func (c *LinuxCollector) countFDs(pid int) (uint64, error) {
+	dir := c.procPath(strconv.Itoa(pid), "fd")
+	f, err := os.Open(dir)
+	if err != nil {
+		return 0, err
+	}
+	if fi, err := f.Stat(); err == nil && fi.Size() > 0 {
+		f.Close()
+		return uint64(fi.Size()), nil
+	}
+	defer f.Close()
+	names, err := f.Readdirnames(-1)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(names)), nil
+}
+
+// countSockets 统计指向 socket 的 fd 数，需要逐个 readlink，只对入选的进程调用
+func (c *LinuxCollector) countSockets(pid int) uint64 {
+	dir := c.procPath(strconv.Itoa(pid), "fd")
+	f, err := os.Open(dir)
+	if err != nil {
+		return 0
+	}
+	names, _ := f.Readdirnames(-1)
+	f.Close()
+
+	var n uint64
+	for _, name := range names {
+		target, err := os.Readlink(dir + "/" + name)
+		if err == nil && strings.HasPrefix(target, "socket:") {
+			n++
+		}
+	}
+	return n
+}
+
+// readNOFILELimit 读取 /proc/[pid]/limits 中的 Max open files，unlimited 记为 0
+func (c *LinuxCollector) readNOFILELimit(pid int) (soft, hard uint64, err error) {
+	data, err := os.ReadFile(c.procPath(strconv.Itoa(pid), "limits"))
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		rest, ok := strings.CutPrefix(line, "Max open files")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 2 {
+			break
+		}
+		soft, _ = strconv.ParseUint(fields[0], 10, 64)
+		hard, _ = strconv.ParseUint(fields[1], 10, 64)
+		return soft, hard, nil
+	}
+	return 0, 0, nil
+}
+
+// topFDProcesses 选出 fd 数最多的 N 个、占 RLIMIT_NOFILE 比例最高的 N 个，以及所有持续增长的进程
+func topFDProcesses(stats []model.ProcStat, n int) []model.ProcStat {
+	var candidates []model.ProcStat
+	for _, s := range stats {
+		if s.FDs > 0 {
+			candidates = append(candidates, s)
+		}
+	}
+
+	selected := make(map[int]bool)
+	var ret []model.ProcStat
+	add := func(list []model.ProcStat) {
+		for _, p := range list {
+			if !selected[p.PID] {
+				selected[p.PID] = true
+				ret = append(ret, p)
+			}
+		}
+	}
+	add(topProcesses(candidates, n, func(a, b model.ProcStat) bool { return a.FDs > b.FDs }))
+	add(topProcesses(candidates, n, func(a, b model.ProcStat) bool { return a.FDUsedPercent > b.FDUsedPercent }))
+	for _, p := range candidates {
+		if p.FDGrowing {
+			add([]model.ProcStat{p})
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].FDs != ret[j].FDs {
+			return ret[i].FDs > ret[j].FDs
+		}
+		return ret[i].PID < ret[j].PID
+	})
+	return ret
+}
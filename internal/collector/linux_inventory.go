@@ -0,0 +1,214 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"tisminSRETool/internal/model"
+	"tisminSRETool/pkg/utils"
+
+	"golang.org/x/sys/unix"
+)
+
+// DMI 厂商/产品名中的关键字到虚拟化类型的映射，按顺序匹配
+var dmiVirtualization = []struct {
+	keyword string
+	name    string
+}{
+	{"KVM", "kvm"},
+	{"QEMU", "qemu"},
+	{"VMware", "vmware"},
+	{"VirtualBox", "virtualbox"},
+	{"innotek", "virtualbox"},
+	{"Xen", "xen"},
+	{"Microsoft Corporation", "hyperv"},
+	{"Amazon EC2", "amazon"},
+	{"Google", "google"},
+	{"Parallels", "parallels"},
+	{"Bochs", "bochs"},
+	{"OpenStack", "openstack"},
+}
+
+// 1 号进程 cgroup 路径中的关键字到容器运行时的映射
+var cgroupContainerRuntimes = []struct {
+	keyword string
+	name    string
+}{
+	{"/kubepods", "kubernetes"},
+	{"/docker", "docker"},
+	{"/containerd", "containerd"},
+	{"/crio", "cri-o"},
+	{"/libpod", "podman"},
+	{"/lxc", "lxc"},
+}
+
+// hostInfoState 缓存同一次启动期间不变的字段（内核、os-release、cpuinfo、DMI 等），
+// boot_id 变化时重新读取，其余时间每轮只读取启动时间、运行时长等动态数据
+type hostInfoState struct {
+	mu     sync.Mutex
+	bootID string
+	static model.HostInfo
+}
+
+// CollectHostInfo 采集主机清单信息：内核、发行版、启动时间、CPU 型号、虚拟化与容器环境
+// 各项均为尽力读取，单项缺失不视为错误
+func (c *LinuxCollector) CollectHostInfo(ctx context.Context) (model.HostInfo, error) {
+	bootID := readSysfsString(c.procPath("sys", "kernel", "random", "boot_id"))
+
+	c.hostInfo.mu.Lock()
+	defer c.hostInfo.mu.Unlock()
+	info := c.hostInfo.static
+	// 读取不到 boot_id 时无法判断是否重启，每轮重新读取
+	if bootID == "" || bootID != c.hostInfo.bootID {
+		var err error
+		if info, err = c.readStaticHostInfo(ctx); err != nil {
+			return model.HostInfo{}, err
+		}
+		c.hostInfo.bootID = bootID
+		c.hostInfo.static = info
+	}
+	info.BootID = bootID
+
+	if line, err := utils.ReadLine(c.procPath("stat"), "btime"); err == nil {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			info.BootTime, _ = strconv.ParseInt(fields[1], 10, 64)
+		}
+	}
+	if fields := strings.Fields(readSysfsString(c.procPath("uptime"))); len(fields) > 0 {
+		info.UptimeSeconds, _ = strconv.ParseFloat(fields[0], 64)
+	}
+	if memTotal, err := c.readMemTotal(); err == nil {
+		info.MemTotal = memTotal
+	}
+	info.Container = c.detectContainer()
+	return info, nil
+}
+
+// readStaticHostInfo 读取启动后不会变化的字段
+func (c *LinuxCollector) readStaticHostInfo(ctx context.Context) (model.HostInfo, error) {
+	var info model.HostInfo
+
+	info.KernelRelease = readSysfsString(c.procPath("sys", "kernel", "osrelease"))
+	info.KernelVersion = readSysfsString(c.procPath("sys", "kernel", "version"))
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err == nil {
+		info.Arch = unix.ByteSliceToString(uts.Machine[:])
+	}
+
+	c.readOSRelease(&info)
+	info.MachineID = readSysfsString(c.rootPath("/etc/machine-id"))
+	if info.MachineID == "" {
+		info.MachineID = readSysfsString(c.rootPath("/var/lib/dbus/machine-id"))
+	}
+
+	hypervisorFlag, err := c.readCPUModel(ctx, &info)
+	if err != nil {
+		return model.HostInfo{}, err
+	}
+	info.Virtualization = c.detectVirtualization(hypervisorFlag)
+	return info, nil
+}
+
+// readOSRelease 读取宿主机 os-release，/etc 下不存在时回退到 /usr/lib
+func (c *LinuxCollector) readOSRelease(info *model.HostInfo) {
+	lines, err := utils.ReadLines(c.rootPath("/etc/os-release"))
+	if err != nil {
+		if lines, err = utils.ReadLines(c.rootPath("/usr/lib/os-release")); err != nil {
+			return
+		}
+	}
+	for _, line := range lines {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		switch key {
+		case "ID":
+			info.OSID = value
+		case "VERSION_ID":
+			info.OSVersion = value
+		case "PRETTY_NAME":
+			info.OSName = value
+		}
+	}
+}
+
+// readCPUModel 从 /proc/cpuinfo 读取 CPU 型号与逻辑核数，并返回是否带有 hypervisor 标志
+func (c *LinuxCollector) readCPUModel(ctx context.Context, info *model.HostInfo) (bool, error) {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("cpuinfo"), 0, -1)
+	if err != nil {
+		return false, err
+	}
+	hypervisor := false
+	for _, line := range lines {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "processor":
+			info.CPUCount++
+		// x86 为 model name，部分 ARM 内核只提供 Hardware 或 Model
+		case "model name", "Hardware", "Model":
+			if info.CPUModel == "" {
+				info.CPUModel = value
+			}
+		case "flags":
+			if !hypervisor {
+				hypervisor = strings.Contains(" "+value+" ", " hypervisor ")
+			}
+		}
+	}
+	return hypervisor, nil
+}
+
+// detectVirtualization 依次根据 DMI 信息、/sys/hypervisor 与 CPU hypervisor 标志判断虚拟化类型
+func (c *LinuxCollector) detectVirtualization(hypervisorFlag bool) string {
+	var dmi []string
+	for _, name := range []string{"sys_vendor", "product_name", "bios_vendor"} {
+		dmi = append(dmi, readSysfsString(c.sysPath("class", "dmi", "id", name)))
+	}
+	joined := strings.Join(dmi, " ")
+	for _, v := range dmiVirtualization {
+		if strings.Contains(joined, v.keyword) {
+			return v.name
+		}
+	}
+	if t := readSysfsString(c.sysPath("hypervisor", "type")); t != "" {
+		return t
+	}
+	if hypervisorFlag {
+		return "unknown"
+	}
+	return "none"
+}
+
+// detectContainer 判断被监控的根文件系统是否运行在容器中
+// 通过 rootfs/procfs 挂载宿主机目录时，检测的是宿主机而不是采集器所在的容器
+func (c *LinuxCollector) detectContainer() string {
+	if data, err := os.ReadFile(c.procPath("1", "environ")); err == nil {
+		for _, kv := range strings.Split(string(data), "\x00") {
+			if v, ok := strings.CutPrefix(kv, "container="); ok && v != "" {
+				return v
+			}
+		}
+	}
+	if _, err := os.Stat(c.rootPath("/.dockerenv")); err == nil {
+		return "docker"
+	}
+	if _, err := os.Stat(c.rootPath("/run/.containerenv")); err == nil {
+		return "podman"
+	}
+	if data, err := os.ReadFile(c.procPath("1", "cgroup")); err == nil {
+		for _, rt := range cgroupContainerRuntimes {
+			if strings.Contains(string(data), rt.keyword) {
+				return rt.name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"tisminSRETool/internal/model"
+	"tisminSRETool/pkg/utils"
+)
+
+// CollectIRQ 读取 /proc/softirqs 与 /proc/interrupts 的逐 CPU 计数
+// 两个文件首行均为 CPU 列名，离线的 CPU 不出现，因此列顺序以首行为准
+func (c *LinuxCollector) CollectIRQ(ctx context.Context) (model.IRQStat, error) {
+	var ret model.IRQStat
+
+	cpus, softirqs, err := readIRQTable(ctx, c.procPath("softirqs"))
+	if err != nil {
+		return model.IRQStat{}, err
+	}
+	ret.CPUs = cpus
+	for _, row := range softirqs {
+		ret.SoftIRQs = append(ret.SoftIRQs, model.SoftIRQStat{Type: row.name, PerCPU: row.counts, Total: row.total})
+	}
+
+	_, interrupts, err := readIRQTable(ctx, c.procPath("interrupts"))
+	if err != nil {
+		return model.IRQStat{}, err
+	}
+	for _, row := range interrupts {
+		// 从未触发过的中断线没有排查价值，跳过以控制指标数量
+		if row.total == 0 {
+			continue
+		}
+		ret.Interrupts = append(ret.Interrupts, model.InterruptStat{IRQ: row.name, Device: row.desc, PerCPU: row.counts, Total: row.total})
+	}
+	return ret, nil
+}
+
+type irqRow struct {
+	name   string
+	counts []uint64
+	total  uint64
+	desc   string
+}
+
+// readIRQTable 解析 "名称: 各 CPU 计数 [描述]" 形式的表格
+// interrupts 中 ERR/MIS 等行只有一个全局计数，此时 counts 长度小于 CPU 数
+func readIRQTable(ctx context.Context, filename string) ([]string, []irqRow, error) {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, filename, 0, -1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(lines) == 0 {
+		return nil, nil, fmt.Errorf("empty %s", filename)
+	}
+	var cpus []string
+	for _, f := range strings.Fields(lines[0]) {
+		cpus = append(cpus, strings.TrimPrefix(f, "CPU"))
+	}
+
+	var rows []irqRow
+	for _, line := range lines[1:] {
+		name, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		row := irqRow{name: strings.TrimSpace(name)}
+		fields := strings.Fields(rest)
+		i := 0
+		for ; i < len(fields) && i < len(cpus); i++ {
+			v, err := strconv.ParseUint(fields[i], 10, 64)
+			if err != nil {
+				break
+			}
+			row.counts = append(row.counts, v)
+			row.total += v
+		}
+		row.desc = strings.Join(fields[i:], " ")
+		rows = append(rows, row)
+	}
+	return cpus, rows, nil
+}
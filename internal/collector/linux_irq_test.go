@@ -0,0 +1,42 @@
+package collector
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"tisminSRETool/internal/model"
+)
+
+func TestCollectIRQ(t *testing.T) {
+	c := &LinuxCollector{procRoot: "testdata/proc"}
+	got, err := c.CollectIRQ(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := model.IRQStat{
+		CPUs: []string{"0", "1"},
+		SoftIRQs: []model.SoftIRQStat{
+			{Type: "HI", PerCPU: []uint64{1, 0}, Total: 1},
+			{Type: "TIMER", PerCPU: []uint64{123456, 234567}, Total: 358023},
+			{Type: "NET_TX", PerCPU: []uint64{10, 20}, Total: 30},
+			{Type: "NET_RX", PerCPU: []uint64{50000, 300}, Total: 50300},
+			{Type: "BLOCK", PerCPU: []uint64{700, 800}, Total: 1500},
+			{Type: "IRQ_POLL", PerCPU: []uint64{0, 0}, Total: 0},
+			{Type: "TASKLET", PerCPU: []uint64{5, 6}, Total: 11},
+			{Type: "SCHED", PerCPU: []uint64{90000, 80000}, Total: 170000},
+			{Type: "HRTIMER", PerCPU: []uint64{0, 1}, Total: 1},
+			{Type: "RCU", PerCPU: []uint64{40000, 30000}, Total: 70000},
+		},
+		// 计数为 0 的 rtc0 与 ERR 跳过；MIS 只有一个全局计数
+		Interrupts: []model.InterruptStat{
+			{IRQ: "0", Device: "IO-APIC 2-edge timer", PerCPU: []uint64{35, 0}, Total: 35},
+			{IRQ: "24", Device: "PCI-MSI 524288-edge eth0-TxRx-0", PerCPU: []uint64{9000, 12}, Total: 9012},
+			{IRQ: "NMI", Device: "Non-maskable interrupts", PerCPU: []uint64{4, 5}, Total: 9},
+			{IRQ: "LOC", Device: "Local timer interrupts", PerCPU: []uint64{1000000, 2000000}, Total: 3000000},
+			{IRQ: "MIS", PerCPU: []uint64{3}, Total: 3},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectIRQ mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
@@ -0,0 +1,258 @@
+package collector
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"tisminSRETool/internal/model"
+)
+
+const (
+	defaultKmsgPath = "/dev/kmsg"
+
+	kmsgRecentSize    = 20               // Metrics 中保留的最近事件数
+	kmsgEventBuffer   = 64               // 事件通道缓冲，告警处理不及时时丢弃事件但仍计数
+	kmsgPollInterval  = time.Second      // 普通文件读到末尾后的轮询间隔
+	kmsgRetryInterval = 10 * time.Second // 打开或读取失败后的重试间隔
+)
+
+// kernelEventRules 按顺序匹配，命中第一条即停止
+// 命名分组 process/pid/device 会填入事件对应字段
+var kernelEventRules = []struct {
+	typ string
+	re  *regexp.Regexp
+}{
+	// Out of memory: Killed process 1234 (java) total-vm:...
+	// Memory cgroup out of memory: Killed process 1234 (java) ...
+	{model.KernelEventOOMKill, regexp.MustCompile(`Killed process (?P<pid>\d+) \((?P<process>[^)]+)\)`)},
+	// INFO: task java:1234 blocked for more than 120 seconds.
+	{model.KernelEventHungTask, regexp.MustCompile(`task (?P<process>\S+):(?P<pid>\d+) blocked for more than \d+ seconds`)},
+	// EXT4-fs error (device sda1): ext4_find_entry:1455: ...
+	{model.KernelEventFSError, regexp.MustCompile(`EXT[234]-fs error \(device (?P<device>[^)]+)\)`)},
+	// EXT4-fs (sda1): Remounting filesystem read-only
+	{model.KernelEventFSError, regexp.MustCompile(`EXT[234]-fs \((?P<device>[^)]+)\): [Rr]emounting filesystem read-only`)},
+	// XFS (sdb1): Corruption detected. / metadata I/O error / Filesystem has been shut down
+	{model.KernelEventFSError, regexp.MustCompile(`XFS \((?P<device>[^)]+)\): .*(?:[Cc]orruption|I/O error|[Ss]hut(?:ting)? down)`)},
+	// blk_update_request: I/O error, dev sda, sector 12345
+	// Buffer I/O error on dev sda1, logical block 0
+	{model.KernelEventIOError, regexp.MustCompile(`I/O error,? (?:on )?dev (?P<device>[^,\s]+)`)},
+	// sd 0:0:0:0: [sda] tag#0 FAILED Result: hostbyte=DID_OK driverbyte=DRIVER_SENSE
+	{model.KernelEventIOError, regexp.MustCompile(`sd \S+: \[(?P<device>[^\]]+)\] .*FAILED Result`)},
+	// e1000e: eth0 NIC Link is Down / ixgbe 0000:01:00.0 eth0: NIC Link is Up 10 Gbps
+	{model.KernelEventLinkDown, regexp.MustCompile(`(?P<device>[^\s:]+):? NIC Link is Down`)},
+	{model.KernelEventLinkUp, regexp.MustCompile(`(?P<device>[^\s:]+):? NIC Link is Up`)},
+	// mlx5_core 0000:3b:00.0 ens1f0: Link down
+	{model.KernelEventLinkDown, regexp.MustCompile(`(?P<device>[^\s:]+): Link down`)},
+	{model.KernelEventLinkUp, regexp.MustCompile(`(?P<device>[^\s:]+): Link up`)},
+}
+
+// dmesg 文本中的时间戳前缀，如 "[  123.456789] "
+var dmesgTimestampRe = regexp.MustCompile(`^\[\s*\d+\.\d+\]\s*`)
+
+// kmsgWatcher 持续读取内核日志并识别事件，计数与最近事件供 Collect 使用
+type kmsgWatcher struct {
+	path string
+
+	once    sync.Once
+	mu      sync.Mutex
+	counts  map[string]uint64
+	recent  []model.KernelEvent
+	lastErr error
+}
+
+func newKmsgWatcher(cfg model.KmsgConfig) *kmsgWatcher {
+	if !cfg.Enabled {
+		return nil
+	}
+	path := cfg.Path
+	if path == "" {
+		path = defaultKmsgPath
+	}
+	return &kmsgWatcher{path: path, counts: make(map[string]uint64)}
+}
+
+// Events 启动内核日志监听并返回事件通道，事件不等待采集周期，由调用方立即送入告警流程
+// 未启用时返回 nil；多次调用只启动一次监听，之后返回 nil
+func (c *LinuxCollector) Events(ctx context.Context) <-chan model.KernelEvent {
+	w := c.kmsg
+	if w == nil {
+		return nil
+	}
+	var ch chan model.KernelEvent
+	w.once.Do(func() {
+		ch = make(chan model.KernelEvent, kmsgEventBuffer)
+		go w.run(ctx, ch)
+	})
+	return ch
+}
+
+// snapshot 返回累计计数和最近事件，以及最近一次读取错误
+func (w *kmsgWatcher) snapshot() (model.KernelLogStat, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	stat := model.KernelLogStat{
+		Events: make(map[string]uint64, len(w.counts)),
+		Recent: append([]model.KernelEvent(nil), w.recent...),
+	}
+	for k, v := range w.counts {
+		stat.Events[k] = v
+	}
+	return stat, w.lastErr
+}
+
+func (w *kmsgWatcher) setErr(err error) {
+	w.mu.Lock()
+	w.lastErr = err
+	w.mu.Unlock()
+}
+
+func (w *kmsgWatcher) run(ctx context.Context, out chan<- model.KernelEvent) {
+	defer close(out)
+	for {
+		err := w.follow(ctx, out)
+		if ctx.Err() != nil {
+			return
+		}
+		w.setErr(err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(kmsgRetryInterval):
+		}
+	}
+}
+
+// follow 从当前末尾开始读取，只处理监听启动后产生的日志，避免采集器重启时重复告警
+// /dev/kmsg 每次 read 返回一条记录，读到末尾时阻塞；普通文件读到末尾后轮询，被截断时从头读
+func (w *kmsgWatcher) follow(ctx context.Context, out chan<- model.KernelEvent) error {
+	f, err := os.Open(w.path)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// 关闭文件以唤醒阻塞在 /dev/kmsg 上的 read
+	go func() {
+		<-ctx.Done()
+		f.Close()
+	}()
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	w.setErr(nil)
+
+	r := bufio.NewReaderSize(f, 16*1024)
+	var pending string
+	for {
+		line, err := r.ReadString('\n')
+		offset += int64(len(line))
+		switch {
+		case err == nil:
+			w.handleLine(pending+line, out)
+			pending = ""
+		case errors.Is(err, syscall.EPIPE):
+			// 读取速度跟不上，环形缓冲区中的记录已被覆盖，从下一条继续
+			continue
+		case errors.Is(err, io.EOF):
+			pending += line
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(kmsgPollInterval):
+			}
+			if fi, err := f.Stat(); err == nil && fi.Mode().IsRegular() && fi.Size() < offset {
+				if offset, err = f.Seek(0, io.SeekStart); err != nil {
+					return err
+				}
+				pending = ""
+				r.Reset(f)
+			}
+		default:
+			return err
+		}
+	}
+}
+
+func (w *kmsgWatcher) handleLine(line string, out chan<- model.KernelEvent) {
+	msg, ok := parseKmsgLine(line)
+	if !ok {
+		return
+	}
+	ev, ok := classifyKernelMessage(msg)
+	if !ok {
+		return
+	}
+	ev.Timestamp = time.Now()
+
+	w.mu.Lock()
+	w.counts[ev.Type]++
+	w.recent = append(w.recent, ev)
+	if len(w.recent) > kmsgRecentSize {
+		w.recent = w.recent[len(w.recent)-kmsgRecentSize:]
+	}
+	w.mu.Unlock()
+
+	select {
+	case out <- ev:
+	default:
+	}
+}
+
+// parseKmsgLine 提取日志正文
+// /dev/kmsg 格式为 "pri,seq,usec,flags;message"，续行以空格开头（KEY=value），直接忽略
+// 普通文件按 dmesg 输出处理，去掉 "[ 123.456] " 时间戳前缀
+func parseKmsgLine(line string) (string, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" || line[0] == ' ' {
+		return "", false
+	}
+	if prefix, msg, ok := strings.Cut(line, ";"); ok && isKmsgPrefix(prefix) {
+		return msg, true
+	}
+	return dmesgTimestampRe.ReplaceAllString(line, ""), true
+}
+
+func isKmsgPrefix(prefix string) bool {
+	fields := strings.Split(prefix, ",")
+	if len(fields) < 4 {
+		return false
+	}
+	for _, f := range fields[:3] {
+		if _, err := strconv.ParseUint(f, 10, 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func classifyKernelMessage(msg string) (model.KernelEvent, bool) {
+	for _, rule := range kernelEventRules {
+		m := rule.re.FindStringSubmatch(msg)
+		if m == nil {
+			continue
+		}
+		ev := model.KernelEvent{Type: rule.typ, Message: msg}
+		for i, name := range rule.re.SubexpNames() {
+			switch name {
+			case "process":
+				ev.Process = m[i]
+			case "pid":
+				ev.PID, _ = strconv.Atoi(m[i])
+			case "device":
+				ev.Device = m[i]
+			}
+		}
+		return ev, true
+	}
+	return model.KernelEvent{}, false
+}
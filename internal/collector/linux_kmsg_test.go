@@ -0,0 +1,149 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"tisminSRETool/internal/model"
+)
+
+func TestParseKmsgLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+		ok   bool
+	}{
+		{"kmsg record", "6,1234,5678901,-;Out of memory: Killed process 1234 (java)\n", "Out of memory: Killed process 1234 (java)", true},
+		{"kmsg record with caller", "4,1,2,-,caller=T1;eth0: Link up", "eth0: Link up", true},
+		{"continuation line", " SUBSYSTEM=pci", "", false},
+		{"empty line", "\n", "", false},
+		{"dmesg timestamp", "[  123.456789] eth0: Link down", "eth0: Link down", true},
+		{"plain text with semicolon", "plain message; not a record", "plain message; not a record", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseKmsgLine(tt.line)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("parseKmsgLine(%q) = %q, %v; want %q, %v", tt.line, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestClassifyKernelMessage(t *testing.T) {
+	tests := []struct {
+		msg     string
+		typ     string
+		process string
+		pid     int
+		device  string
+	}{
+		{"Out of memory: Killed process 1234 (java) total-vm:8123456kB, anon-rss:4000000kB", model.KernelEventOOMKill, "java", 1234, ""},
+		{"Memory cgroup out of memory: Killed process 42 (nginx) total-vm:1024kB", model.KernelEventOOMKill, "nginx", 42, ""},
+		{"INFO: task kworker/u8:2:311 blocked for more than 120 seconds.", model.KernelEventHungTask, "kworker/u8:2", 311, ""},
+		{"EXT4-fs error (device sda1): ext4_find_entry:1455: inode #2: comm ls: reading directory lblock 0", model.KernelEventFSError, "", 0, "sda1"},
+		{"EXT4-fs (dm-0): Remounting filesystem read-only", model.KernelEventFSError, "", 0, "dm-0"},
+		{"XFS (sdb1): Corruption detected. Unmount and run xfs_repair", model.KernelEventFSError, "", 0, "sdb1"},
+		{"XFS (sdb1): metadata I/O error in \"xfs_trans_read_buf_map\" at daddr 0x2 len 1 error 5", model.KernelEventFSError, "", 0, "sdb1"},
+		{"blk_update_request: I/O error, dev sda, sector 12345 op 0x0:(READ) flags 0x0", model.KernelEventIOError, "", 0, "sda"},
+		{"Buffer I/O error on dev sda1, logical block 0, async page read", model.KernelEventIOError, "", 0, "sda1"},
+		{"sd 0:0:0:0: [sdc] tag#0 FAILED Result: hostbyte=DID_OK driverbyte=DRIVER_SENSE", model.KernelEventIOError, "", 0, "sdc"},
+		{"e1000e: eth0 NIC Link is Down", model.KernelEventLinkDown, "", 0, "eth0"},
+		{"ixgbe 0000:01:00.0 eth1: NIC Link is Up 10 Gbps, Flow Control: RX/TX", model.KernelEventLinkUp, "", 0, "eth1"},
+		{"mlx5_core 0000:3b:00.0 ens1f0: Link down", model.KernelEventLinkDown, "", 0, "ens1f0"},
+		{"virtio_net virtio0 ens3: Link up", model.KernelEventLinkUp, "", 0, "ens3"},
+	}
+	for _, tt := range tests {
+		ev, ok := classifyKernelMessage(tt.msg)
+		if !ok {
+			t.Errorf("classifyKernelMessage(%q) not matched", tt.msg)
+			continue
+		}
+		if ev.Type != tt.typ || ev.Process != tt.process || ev.PID != tt.pid || ev.Device != tt.device || ev.Message != tt.msg {
+			t.Errorf("classifyKernelMessage(%q) = %+v; want type=%s process=%q pid=%d device=%q", tt.msg, ev, tt.typ, tt.process, tt.pid, tt.device)
+		}
+	}
+
+	for _, msg := range []string{
+		"usb 1-1: new high-speed USB device number 2 using xhci_hcd",
+		"EXT4-fs (sda1): mounted filesystem with ordered data mode",
+	} {
+		if ev, ok := classifyKernelMessage(msg); ok {
+			t.Errorf("classifyKernelMessage(%q) = %+v; want no match", msg, ev)
+		}
+	}
+}
+
+// TestKmsgFollow 以普通文件模拟 dmesg 输出：启动前的内容不处理，半行等到写完再处理，截断后从头读
+func TestKmsgFollow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dmesg")
+	if err := os.WriteFile(path, []byte("[    1.000000] Out of memory: Killed process 1 (old) total-vm:1kB\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w := newKmsgWatcher(model.KmsgConfig{Enabled: true, Path: path})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make(chan model.KernelEvent, kmsgEventBuffer)
+	done := make(chan error, 1)
+	go func() { done <- w.follow(ctx, out) }()
+	// 等待 follow 定位到文件末尾
+	time.Sleep(200 * time.Millisecond)
+
+	appendFile(t, path, "[    2.000000] Out of memory: Killed process 1234 (ja")
+	time.Sleep(200 * time.Millisecond)
+	appendFile(t, path, "va) total-vm:1kB\n")
+	ev := waitKernelEvent(t, out)
+	if ev.Type != model.KernelEventOOMKill || ev.Process != "java" || ev.PID != 1234 {
+		t.Fatalf("got %+v; want oom_kill java 1234", ev)
+	}
+
+	if err := os.WriteFile(path, []byte("[ 3.0] e1000e: eth0 NIC Link is Down\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ev = waitKernelEvent(t, out)
+	if ev.Type != model.KernelEventLinkDown || ev.Device != "eth0" {
+		t.Fatalf("got %+v after truncation; want link_down eth0", ev)
+	}
+
+	stat, err := w.snapshot()
+	if err != nil {
+		t.Fatalf("snapshot error: %v", err)
+	}
+	if stat.Events[model.KernelEventOOMKill] != 1 || stat.Events[model.KernelEventLinkDown] != 1 || len(stat.Recent) != 2 {
+		t.Errorf("snapshot = %+v; want one oom_kill and one link_down", stat)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("follow did not return after cancel")
+	}
+}
+
+func appendFile(t *testing.T, path, data string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitKernelEvent(t *testing.T, out <-chan model.KernelEvent) model.KernelEvent {
+	t.Helper()
+	select {
+	case ev := <-out:
+		return ev
+	case <-time.After(5 * kmsgPollInterval):
+		t.Fatal("timed out waiting for kernel event")
+	}
+	return model.KernelEvent{}
+}
@@ -0,0 +1,98 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"tisminSRETool/internal/model"
+	"tisminSRETool/pkg/utils"
+)
+
+// CollectLimits 读取文件句柄、inode 表与进程/线程数的内核上限及当前用量
+func (c *LinuxCollector) CollectLimits(ctx context.Context) (model.LimitsStat, error) {
+	var ret model.LimitsStat
+
+	// file-nr: 已分配句柄数、已分配未使用数（2.6 起恒为 0）、上限
+	fileNr, err := readUintFields(c.procPath("sys", "fs", "file-nr"), 3)
+	if err != nil {
+		return model.LimitsStat{}, err
+	}
+	ret.FileHandlesAllocated = fileNr[0] - min(fileNr[1], fileNr[0])
+	ret.FileHandlesMax = fileNr[2]
+	// file-nr 中的上限与 file-max 一致，file-max 只作为兜底
+	if ret.FileHandlesMax == 0 {
+		if v, err := readUintFile(c.procPath("sys", "fs", "file-max")); err == nil {
+			ret.FileHandlesMax = v
+		}
+	}
+
+	// inode-nr: 已分配 inode 对象数、其中空闲数
+	if inodeNr, err := readUintFields(c.procPath("sys", "fs", "inode-nr"), 2); err == nil {
+		ret.InodesAllocated = inodeNr[0]
+		ret.InodesFree = inodeNr[1]
+	}
+
+	if ret.PIDMax, err = readUintFile(c.procPath("sys", "kernel", "pid_max")); err != nil {
+		return model.LimitsStat{}, err
+	}
+	if ret.ThreadsMax, err = readUintFile(c.procPath("sys", "kernel", "threads-max")); err != nil {
+		return model.LimitsStat{}, err
+	}
+
+	if err := c.readLoadAvgTasks(ctx, &ret); err != nil {
+		return model.LimitsStat{}, err
+	}
+
+	ret.FileHandlesUsedPercent = utils.Pct(ret.FileHandlesAllocated, ret.FileHandlesMax)
+	// loadavg 中的任务数按调度实体计，即线程数，线程同样占用 PID
+	ret.PIDUsedPercent = utils.Pct(ret.TasksTotal, ret.PIDMax)
+	ret.ThreadsUsedPercent = utils.Pct(ret.TasksTotal, ret.ThreadsMax)
+	return ret, nil
+}
+
+// readLoadAvgTasks 解析 /proc/loadavg 第四列 running/total
+func (c *LinuxCollector) readLoadAvgTasks(ctx context.Context, ret *model.LimitsStat) error {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("loadavg"), 0, 1)
+	if err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("empty /proc/loadavg")
+	}
+	fields := strings.Fields(lines[0])
+	if len(fields) < 4 {
+		return fmt.Errorf("invalid /proc/loadavg: %s", lines[0])
+	}
+	running, total, ok := strings.Cut(fields[3], "/")
+	if !ok {
+		return fmt.Errorf("invalid /proc/loadavg: %s", lines[0])
+	}
+	if ret.TasksRunning, err = strconv.ParseUint(running, 10, 64); err != nil {
+		return err
+	}
+	if ret.TasksTotal, err = strconv.ParseUint(total, 10, 64); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readUintFields 读取一行以空白分隔的整数，至少需要 n 个
+func readUintFields(filename string, n int) ([]uint64, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < n {
+		return nil, fmt.Errorf("invalid format of %s: %q", filename, strings.TrimSpace(string(data)))
+	}
+	ret := make([]uint64, n)
+	for i := range ret {
+		if ret[i], err = strconv.ParseUint(fields[i], 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,403 @@
+package collector
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"tisminSRETool/internal/model"
+	"unicode/utf8"
+)
+
+const (
+	logOffsetsFile = "log_offsets.json"
+
+	maxLogReadBytes     = 32 << 20 // 单个文件每轮最多读取的字节数，积压的部分下一轮继续
+	maxLogSeriesPerRule = 500      // 单条规则最多的标签组合数，超出的取值记为 other
+	maxLogLineLen       = 512      // LastLine 保留的最大长度
+)
+
+type logRule struct {
+	name      string
+	paths     []string
+	re        *regexp.Regexp
+	threshold float64
+	level     string
+	series    int
+}
+
+// logOffset 持久化的读取位置，inode 不一致说明文件在采集器停止期间被轮转
+type logOffset struct {
+	Inode  uint64 `json:"inode"`
+	Offset int64  `json:"offset"`
+}
+
+// tailFile 保持打开的文件句柄，文件被 rename 轮转后仍可读完旧文件的剩余内容
+type tailFile struct {
+	f      *os.File
+	inode  uint64
+	offset int64
+	size   int64
+	rules  []*logRule
+}
+
+// logTailer 跟踪日志文件并按规则计数，状态跨采集周期保留
+type logTailer struct {
+	rules     []*logRule
+	stateFile string
+
+	mu      sync.Mutex
+	started bool
+	saved   map[string]logOffset
+	files   map[string]*tailFile
+	rotated map[uint64]*tailFile // 本轮被 rename 轮转的旧文件，新名字也匹配规则时沿用读取位置
+	counts  map[string]*model.LogMatchStat
+}
+
+func newLogTailer(cfg model.LogTailConfig, stateDir string) *logTailer {
+	if !cfg.Enabled {
+		return nil
+	}
+	t := &logTailer{
+		files:   make(map[string]*tailFile),
+		rotated: make(map[uint64]*tailFile),
+		counts:  make(map[string]*model.LogMatchStat),
+	}
+	if stateDir != "" {
+		t.stateFile = filepath.Join(stateDir, logOffsetsFile)
+	}
+	for _, rc := range cfg.Rules {
+		re, err := regexp.Compile(rc.Regex)
+		if err == nil {
+			err = checkLogGroupNames(re)
+		}
+		if err != nil || rc.Name == "" {
+			log.Printf("invalid log rule %q: %v", rc.Name, err)
+			continue
+		}
+		level := rc.Level
+		if level == "" {
+			level = "warning"
+		}
+		t.rules = append(t.rules, &logRule{
+			name:      rc.Name,
+			paths:     rc.Paths,
+			re:        re,
+			threshold: rc.Threshold,
+			level:     level,
+		})
+	}
+	if len(t.rules) == 0 {
+		return nil
+	}
+	return t
+}
+
+// checkLogGroupNames 命名分组会作为指标标签输出，不能与固定标签 host/rule 重名，且需是合法的标签名
+func checkLogGroupNames(re *regexp.Regexp) error {
+	for _, name := range re.SubexpNames() {
+		switch {
+		case name == "":
+		case name == "host" || name == "rule":
+			return fmt.Errorf("named group %q conflicts with built-in label", name)
+		case strings.HasPrefix(name, "__") || (name[0] >= '0' && name[0] <= '9'):
+			return fmt.Errorf("named group %q is not a valid label name", name)
+		}
+	}
+	return nil
+}
+
+// CollectLogs 读取各规则匹配到的日志文件新增内容并计数
+// 首次启动时从文件末尾开始，之后新出现的文件（如轮转后新建的）从头读取；
+// 读取位置保存在 state_dir 中，采集器重启后从上次位置继续
+func (c *LinuxCollector) CollectLogs(ctx context.Context) ([]model.LogMatchStat, []model.LogFileStat, error) {
+	t := c.logs
+	if t == nil {
+		return nil, nil, nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var firstErr error
+	if !t.started {
+		if err := t.loadOffsets(); err != nil {
+			firstErr = err
+		}
+	}
+
+	// 同一文件可能被多条规则匹配，每轮只读一次；路径按宿主机路径记录，不带 rootfs 前缀
+	targets := make(map[string][]*logRule)
+	for _, rule := range t.rules {
+		for _, pattern := range rule.paths {
+			matches, err := filepath.Glob(c.rootPath(pattern))
+			if err != nil {
+				continue
+			}
+			for _, p := range matches {
+				p = c.hostPath(p)
+				// 规则的多个 pattern 匹配到同一文件时只计一次
+				if n := len(targets[p]); n > 0 && targets[p][n-1] == rule {
+					continue
+				}
+				targets[p] = append(targets[p], rule)
+			}
+		}
+	}
+	paths := make([]string, 0, len(targets))
+	for p := range targets {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		if err := t.tail(p, c.rootPath(p), targets[p]); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	// 文件被删除或不再匹配时读完剩余内容后关闭
+	for p, tf := range t.files {
+		if _, ok := targets[p]; ok {
+			continue
+		}
+		t.read(tf)
+		tf.f.Close()
+		delete(t.files, p)
+	}
+	for inode, tf := range t.rotated {
+		tf.f.Close()
+		delete(t.rotated, inode)
+	}
+	t.started = true
+
+	if err := t.saveOffsets(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return t.snapshot(), t.fileStats(), firstErr
+}
+
+// tail 读取单个文件的新增内容，处理轮转（inode 变化）与截断
+// path 为宿主机路径，用于记录读取位置；name 为实际打开的路径
+func (t *logTailer) tail(path, name string, rules []*logRule) error {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	if !fi.Mode().IsRegular() {
+		return nil
+	}
+	inode := fileInode(fi)
+
+	tf := t.files[path]
+	if tf != nil && tf.inode != inode {
+		// 旧文件已被 rename，先读完再切换到新文件；旧文件暂不关闭，新名字（如 app.log.1）也匹配时继续使用
+		t.read(tf)
+		t.rotated[tf.inode] = tf
+		delete(t.files, path)
+		tf = nil
+	}
+	if tf == nil {
+		tf = t.adopt(path, inode)
+	}
+	if tf == nil {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		tf = &tailFile{f: f, inode: inode}
+		saved, ok := t.saved[path]
+		switch {
+		case ok && saved.Inode == inode:
+			tf.offset = saved.Offset
+		case ok:
+			// 采集器停止期间发生了轮转，旧文件未读完的部分已无法定位
+			tf.offset = 0
+		case !t.started:
+			// 首次启动不回放历史日志
+			tf.offset = fi.Size()
+		default:
+			// 启动后新出现的文件（如轮转后新建的）从头读取
+		}
+		t.files[path] = tf
+	}
+	if fi.Size() < tf.offset {
+		// copytruncate 方式轮转，文件被截断
+		tf.offset = 0
+	}
+	tf.size = fi.Size()
+	tf.rules = rules
+	return t.read(tf)
+}
+
+// adopt 查找已在其他路径下跟踪的同一文件，rename 轮转后的新名字也匹配规则时从原位置继续读，避免从头重复计数
+func (t *logTailer) adopt(path string, inode uint64) *tailFile {
+	if tf, ok := t.rotated[inode]; ok {
+		delete(t.rotated, inode)
+		t.files[path] = tf
+		return tf
+	}
+	for p, tf := range t.files {
+		if p != path && tf.inode == inode {
+			delete(t.files, p)
+			t.files[path] = tf
+			return tf
+		}
+	}
+	return nil
+}
+
+// read 从上次位置读取完整的行，末尾未写完的半行留到下一轮
+func (t *logTailer) read(tf *tailFile) error {
+	if _, err := tf.f.Seek(tf.offset, io.SeekStart); err != nil {
+		return err
+	}
+	r := bufio.NewReader(io.LimitReader(tf.f, maxLogReadBytes))
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		tf.offset += int64(len(line))
+		t.match(strings.TrimRight(line, "\r\n"), tf.rules)
+	}
+}
+
+func (t *logTailer) match(line string, rules []*logRule) {
+	for _, rule := range rules {
+		m := rule.re.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		var labels map[string]string
+		for i, name := range rule.re.SubexpNames() {
+			if name == "" {
+				continue
+			}
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			// 日志内容不一定是合法 UTF-8，不合法的标签值会导致指标无法输出
+			labels[name] = strings.ToValidUTF8(m[i], "\uFFFD")
+		}
+		key := model.LogMatchStat{Rule: rule.name, Labels: labels}.SeriesKey()
+		stat, ok := t.counts[key]
+		if !ok {
+			// 标签取值过多时归并，避免日志内容导致指标基数失控
+			if rule.series >= maxLogSeriesPerRule {
+				for name := range labels {
+					labels[name] = "other"
+				}
+				key = model.LogMatchStat{Rule: rule.name, Labels: labels}.SeriesKey()
+				stat, ok = t.counts[key]
+			}
+			if !ok {
+				stat = &model.LogMatchStat{
+					Rule:      rule.name,
+					Labels:    labels,
+					Threshold: rule.threshold,
+					Level:     rule.level,
+				}
+				t.counts[key] = stat
+				rule.series++
+			}
+		}
+		stat.Count++
+		if len(line) > maxLogLineLen {
+			// 截断位置退到字符边界，避免切开多字节字符
+			n := maxLogLineLen
+			for n > 0 && !utf8.RuneStart(line[n]) {
+				n--
+			}
+			line = line[:n]
+		}
+		stat.LastLine = line
+	}
+}
+
+func (t *logTailer) snapshot() []model.LogMatchStat {
+	keys := make([]string, 0, len(t.counts))
+	for k := range t.counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	ret := make([]model.LogMatchStat, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, *t.counts[k])
+	}
+	return ret
+}
+
+func (t *logTailer) fileStats() []model.LogFileStat {
+	ret := make([]model.LogFileStat, 0, len(t.files))
+	for p, tf := range t.files {
+		ret = append(ret, model.LogFileStat{Path: p, Size: uint64(tf.size), Offset: uint64(tf.offset)})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Path < ret[j].Path })
+	return ret
+}
+
+func (t *logTailer) loadOffsets() error {
+	t.saved = make(map[string]logOffset)
+	if t.stateFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(t.stateFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(data, &t.saved)
+}
+
+// saveOffsets 只保存仍在跟踪的文件，位置没有变化时不写盘
+// 先写临时文件再 rename，避免断电时留下半截文件
+func (t *logTailer) saveOffsets() error {
+	saved := make(map[string]logOffset, len(t.files))
+	changed := len(t.saved) != len(t.files)
+	for p, tf := range t.files {
+		saved[p] = logOffset{Inode: tf.inode, Offset: tf.offset}
+		if t.saved[p] != saved[p] {
+			changed = true
+		}
+	}
+	t.saved = saved
+	if t.stateFile == "" || !changed {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(t.stateFile), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(t.saved)
+	if err != nil {
+		return err
+	}
+	tmp := t.stateFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, t.stateFile)
+}
+
+func fileInode(fi os.FileInfo) uint64 {
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		return st.Ino
+	}
+	return 0
+}
@@ -0,0 +1,129 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"tisminSRETool/internal/model"
+)
+
+// 两个 pattern 都匹配 app.log，同一行只应计数一次
+var testLogConfig = model.LogTailConfig{
+	Enabled: true,
+	Rules: []model.LogRuleConfig{
+		{Name: "errors", Paths: []string{"/var/log/app.log*", "/var/log/*.log"}, Regex: `(?P<level>ERROR|FATAL)`},
+	},
+}
+
+func collectLogCount(t *testing.T, c *LinuxCollector) (uint64, []model.LogFileStat) {
+	t.Helper()
+	stats, files, err := c.CollectLogs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n uint64
+	for _, s := range stats {
+		n += s.Count
+	}
+	return n, files
+}
+
+func TestCollectLogs(t *testing.T) {
+	root := t.TempDir()
+	stateDir := t.TempDir()
+	dir := filepath.Join(root, "var/log")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	logPath := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(logPath, []byte("ERROR old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &LinuxCollector{rootFS: root, logs: newLogTailer(testLogConfig, stateDir)}
+
+	// 首次启动从文件末尾开始，已有内容不计数
+	n, files := collectLogCount(t, c)
+	want := []model.LogFileStat{{Path: "/var/log/app.log", Size: 10, Offset: 10}}
+	if n != 0 || !reflect.DeepEqual(files, want) {
+		t.Fatalf("first start count=%d files=%+v; want 0, %+v", n, files, want)
+	}
+
+	appendFile(t, logPath, "ERROR a\n")
+	if n, _ = collectLogCount(t, c); n != 1 {
+		t.Fatalf("after append count=%d; want 1", n)
+	}
+
+	// rename 轮转：旧文件追加的内容在新名字下继续读取，新建的 app.log 从头读取
+	if err := os.Rename(logPath, logPath+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, logPath+".1", "ERROR b\n")
+	if err := os.WriteFile(logPath, []byte("FATAL c\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	n, files = collectLogCount(t, c)
+	want = []model.LogFileStat{
+		{Path: "/var/log/app.log", Size: 8, Offset: 8},
+		{Path: "/var/log/app.log.1", Size: 26, Offset: 26},
+	}
+	if n != 3 || !reflect.DeepEqual(files, want) {
+		t.Fatalf("after rename count=%d files=%+v; want 3, %+v", n, files, want)
+	}
+	if n, _ = collectLogCount(t, c); n != 3 {
+		t.Fatalf("rotated file counted again: count=%d; want 3", n)
+	}
+
+	// copytruncate：文件变短后从头读取
+	if err := os.WriteFile(logPath, []byte("ERROR\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ = collectLogCount(t, c); n != 4 {
+		t.Fatalf("after truncate count=%d; want 4", n)
+	}
+
+	// 读取位置按宿主机路径保存，不带 rootfs 前缀
+	data, err := os.ReadFile(filepath.Join(stateDir, logOffsetsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved map[string]logOffset
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved["/var/log/app.log"].Offset != 6 || saved["/var/log/app.log.1"].Offset != 26 {
+		t.Fatalf("saved offsets = %+v; want app.log at 6, app.log.1 at 26", saved)
+	}
+
+	// 重启后从保存的位置继续，停止期间追加的内容不丢失也不重复
+	appendFile(t, logPath, "ERROR e\n")
+	c = &LinuxCollector{rootFS: root, logs: newLogTailer(testLogConfig, stateDir)}
+	if n, _ = collectLogCount(t, c); n != 1 {
+		t.Fatalf("after restart count=%d; want 1", n)
+	}
+}
+
+func TestLogMatchLabels(t *testing.T) {
+	tailer := newLogTailer(model.LogTailConfig{
+		Enabled: true,
+		Rules:   []model.LogRuleConfig{{Name: "user", Paths: []string{"/x"}, Regex: `user=(?P<user>\S+)`}},
+	}, "")
+
+	// 不合法的 UTF-8 标签值被替换，截断不切开多字节字符
+	line := "user=\xff\xfe  " + strings.Repeat("日", maxLogLineLen)
+	tailer.match(line, tailer.rules)
+	stats := tailer.snapshot()
+	if len(stats) != 1 {
+		t.Fatalf("snapshot = %+v; want one series", stats)
+	}
+	if got := stats[0].Labels["user"]; got != "\uFFFD" {
+		t.Errorf("user label = %q; want replacement character", got)
+	}
+	if last := stats[0].LastLine; len(last) > maxLogLineLen || !strings.HasSuffix(last, "日") {
+		t.Errorf("LastLine has %d bytes and ends with %q; want at most %d bytes ending on a full rune", len(last), last[len(last)-1:], maxLogLineLen)
+	}
+}
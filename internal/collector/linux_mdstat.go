@@ -0,0 +1,119 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"tisminSRETool/internal/model"
+	"tisminSRETool/pkg/utils"
+)
+
+var (
+	// [3/2] [UU_]：期望成员数/在线成员数
+	mdDisksRe = regexp.MustCompile(`\[(\d+)/(\d+)\]`)
+	// recovery =  8.5% (89216/1047552) finish=1.2min speed=12345K/sec
+	mdSyncRe   = regexp.MustCompile(`(resync|recovery|reshape|check|repair)\s*=\s*([\d.]+)%`)
+	mdFinishRe = regexp.MustCompile(`finish=([\d.]+)min`)
+	mdSpeedRe  = regexp.MustCompile(`speed=(\d+)K/sec`)
+	// resync=DELAYED / resync=PENDING
+	mdPendingRe = regexp.MustCompile(`(resync|recovery|reshape|check|repair)\s*=\s*(DELAYED|PENDING)`)
+)
+
+// CollectMDStat 解析 /proc/mdstat 中的软 RAID 阵列状态，未加载 md 模块时返回空列表
+func (c *LinuxCollector) CollectMDStat(ctx context.Context) ([]model.MDStat, error) {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("mdstat"), 0, -1)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ret []model.MDStat
+	var cur *model.MDStat
+	for _, line := range lines {
+		if strings.HasPrefix(line, "md") {
+			name, rest, ok := strings.Cut(line, " : ")
+			if !ok {
+				continue
+			}
+			ret = append(ret, parseMDHeader(strings.TrimSpace(name), rest))
+			cur = &ret[len(ret)-1]
+			continue
+		}
+		// 阵列描述以空行结束
+		if cur == nil || strings.TrimSpace(line) == "" {
+			cur = nil
+			continue
+		}
+		parseMDDetail(cur, line)
+	}
+
+	for i := range ret {
+		md := &ret[i]
+		md.Degraded = md.Failed > 0 || (md.DisksTotal > 0 && md.DisksActive < md.DisksTotal)
+	}
+	return ret, nil
+}
+
+// parseMDHeader 解析 "active raid5 sdd1[3](F) sdc1[2]" 形式的首行
+func parseMDHeader(name, rest string) model.MDStat {
+	md := model.MDStat{Name: name}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return md
+	}
+	md.State = fields[0]
+	fields = fields[1:]
+	// active 后可能跟 (auto-read-only)/(read-only)
+	for len(fields) > 0 && strings.HasPrefix(fields[0], "(") {
+		md.State += " " + fields[0]
+		fields = fields[1:]
+	}
+	// inactive 阵列没有 level 字段
+	if len(fields) > 0 && !strings.Contains(fields[0], "[") {
+		md.Level = fields[0]
+		fields = fields[1:]
+	}
+	for _, f := range fields {
+		dev, flags, _ := strings.Cut(f, "[")
+		member := model.MDMember{Name: dev}
+		switch {
+		case strings.Contains(flags, "(F)"):
+			member.State = model.MDMemberFailed
+			md.Failed++
+		case strings.Contains(flags, "(S)"):
+			member.State = model.MDMemberSpare
+			md.Spare++
+		default:
+			member.State = model.MDMemberActive
+		}
+		md.Members = append(md.Members, member)
+	}
+	return md
+}
+
+func parseMDDetail(md *model.MDStat, line string) {
+	if m := mdDisksRe.FindStringSubmatch(line); m != nil && md.DisksTotal == 0 {
+		md.DisksTotal, _ = strconv.Atoi(m[1])
+		md.DisksActive, _ = strconv.Atoi(m[2])
+	}
+	if fields := strings.Fields(line); len(fields) > 1 && fields[1] == "blocks" {
+		md.Blocks, _ = strconv.ParseUint(fields[0], 10, 64)
+	}
+	if m := mdSyncRe.FindStringSubmatch(line); m != nil {
+		md.SyncAction = m[1]
+		md.SyncPercent, _ = strconv.ParseFloat(m[2], 64)
+		if f := mdFinishRe.FindStringSubmatch(line); f != nil {
+			minutes, _ := strconv.ParseFloat(f[1], 64)
+			md.SyncFinishSeconds = minutes * 60
+		}
+		if s := mdSpeedRe.FindStringSubmatch(line); s != nil {
+			md.SyncSpeedKBps, _ = strconv.ParseFloat(s[1], 64)
+		}
+	} else if m := mdPendingRe.FindStringSubmatch(line); m != nil {
+		md.SyncAction = m[1] + " " + strings.ToLower(m[2])
+	}
+}
@@ -0,0 +1,61 @@
+package collector
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"tisminSRETool/internal/model"
+)
+
+func TestCollectMDStat(t *testing.T) {
+	c := &LinuxCollector{procRoot: "testdata/proc"}
+	got, err := c.CollectMDStat(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	active := func(name string) model.MDMember { return model.MDMember{Name: name, State: model.MDMemberActive} }
+	want := []model.MDStat{
+		// 一块盘故障、正在向新盘重建
+		{
+			Name: "md1", State: "active", Level: "raid5",
+			Members: []model.MDMember{
+				active("sde1"),
+				{Name: "sdd1", State: model.MDMemberFailed},
+				active("sdc1"),
+				active("sdb1"),
+				{Name: "sda1", State: model.MDMemberSpare},
+			},
+			Blocks: 2093056, DisksTotal: 3, DisksActive: 2, Failed: 1, Spare: 1, Degraded: true,
+			SyncAction: "recovery", SyncPercent: 12.5, SyncFinishSeconds: 90, SyncSpeedKBps: 10240,
+		},
+		{
+			Name: "md0", State: "active", Level: "raid1",
+			Members: []model.MDMember{active("sdg1"), active("sdf1")},
+			Blocks:  1046528, DisksTotal: 2, DisksActive: 2,
+		},
+		{
+			Name: "md2", State: "active (auto-read-only)", Level: "raid10",
+			Members: []model.MDMember{active("sdk1"), active("sdj1"), active("sdi1"), active("sdh1")},
+			Blocks:  2093056, DisksTotal: 4, DisksActive: 4,
+			SyncAction: "resync pending",
+		},
+		// inactive 阵列没有 level
+		{
+			Name: "md127", State: "inactive",
+			Members: []model.MDMember{{Name: "sdl1", State: model.MDMemberSpare}},
+			Blocks:  1048576, Spare: 1,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectMDStat mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+// 未加载 md 模块时没有 /proc/mdstat
+func TestCollectMDStatMissing(t *testing.T) {
+	c := &LinuxCollector{procRoot: t.TempDir()}
+	got, err := c.CollectMDStat(context.Background())
+	if err != nil || len(got) != 0 {
+		t.Errorf("CollectMDStat() = %+v, %v; want empty without error", got, err)
+	}
+}
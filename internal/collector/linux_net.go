@@ -0,0 +1,93 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"tisminSRETool/internal/model"
+)
+
+// 默认排除的网卡：回环、容器 veth 对以及 docker 网桥
+var defaultExcludeInterfaces = []string{"lo", "veth*", "docker*"}
+
+// ARPHRD_* 链路类型，见 include/uapi/linux/if_arp.h
+var arphrdTypes = map[string]string{
+	"1":     "ether",
+	"24":    "ieee1394",
+	"32":    "infiniband",
+	"512":   "ppp",
+	"768":   "ipip",
+	"769":   "tunnel6",
+	"772":   "loopback",
+	"776":   "sit",
+	"778":   "gre",
+	"823":   "ip6gre",
+	"65534": "none",
+}
+
+// netFilter 网卡过滤规则，命中 include 的网卡优先保留
+type netFilter struct {
+	include []matcher
+	exclude []matcher
+}
+
+// defaultNetFilter 零值 LinuxCollector（netFilter 为 nil）使用的默认规则
+var defaultNetFilter = newNetFilter(model.NetFilterConfig{})
+
+func newNetFilter(cfg model.NetFilterConfig) *netFilter {
+	exclude := cfg.ExcludeInterfaces
+	if len(exclude) == 0 {
+		exclude = defaultExcludeInterfaces
+	}
+	return &netFilter{
+		include: newMatchers(cfg.IncludeInterfaces),
+		exclude: newMatchers(exclude),
+	}
+}
+
+func (f *netFilter) keep(name string) bool {
+	if f == nil {
+		f = defaultNetFilter
+	}
+	if _, ok := matchAny(f.include, name); ok {
+		return true
+	}
+	_, excluded := matchAny(f.exclude, name)
+	return !excluded
+}
+
+// fillNetInterfaceInfo 读取 /sys/class/net/<iface> 下的链路元数据
+// 网卡 down 时 carrier/speed/duplex 读取会返回 EINVAL，此时保持零值
+func (c *LinuxCollector) fillNetInterfaceInfo(stat *model.NetStat) {
+	dir := c.sysPath("class", "net", stat.Name)
+
+	stat.OperState = readSysfsString(filepath.Join(dir, "operstate"))
+	stat.Carrier = readSysfsString(filepath.Join(dir, "carrier")) == "1"
+	stat.Duplex = readSysfsString(filepath.Join(dir, "duplex"))
+	if mtu, err := strconv.ParseUint(readSysfsString(filepath.Join(dir, "mtu")), 10, 64); err == nil {
+		stat.MTU = mtu
+	}
+	// 虚拟网卡或未协商时 speed 为 -1
+	if speed, err := strconv.ParseInt(readSysfsString(filepath.Join(dir, "speed")), 10, 64); err == nil && speed > 0 {
+		stat.SpeedMbps = uint64(speed)
+	}
+
+	typ := readSysfsString(filepath.Join(dir, "type"))
+	if name, ok := arphrdTypes[typ]; ok {
+		typ = name
+	}
+	stat.Type = typ
+	// 物理网卡存在 device 链接指向总线设备
+	if _, err := os.Stat(filepath.Join(dir, "device")); err != nil {
+		stat.Virtual = true
+	}
+}
+
+func readSysfsString(filename string) string {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,106 @@
+package collector
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"tisminSRETool/internal/model"
+	"tisminSRETool/pkg/utils"
+)
+
+// CollectNFS 解析 mountstats 中 NFS 挂载的逐操作 RPC 统计
+// 与 readMountInfo 一样优先读取 1 号进程，容器中得到的是宿主机的挂载；
+// 挂载点按 disk 过滤规则筛选，与容量指标保持一致
+func (c *LinuxCollector) CollectNFS(ctx context.Context) ([]model.NFSMountStat, error) {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("1", "mountstats"), 0, -1)
+	if err != nil {
+		lines, err = utils.ReadLinesOffsetNWithContext(ctx, c.procPath("self", "mountstats"), 0, -1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var ret []model.NFSMountStat
+	var cur *model.NFSMountStat
+	// 同一挂载点被重复挂载时只有最后一次可见，与 CollectDisk 一致只保留最后一条
+	index := make(map[string]int)
+	inOps := false
+	for _, line := range lines {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// device server:/export mounted on /mnt/nfs with fstype nfs4 statvers=1.1
+		if strings.HasPrefix(line, "device ") {
+			cur, inOps = nil, false
+			fields := strings.Fields(line)
+			if len(fields) < 8 || fields[2] != "mounted" || fields[5] != "with" || !strings.HasPrefix(fields[7], "nfs") {
+				continue
+			}
+			m := mountEntry{Source: fields[1], MountPoint: unescapeMountPath(fields[4]), FSType: fields[7]}
+			if keep, _, _ := c.diskFilter.keepMount(m, ""); !keep {
+				continue
+			}
+			stat := model.NFSMountStat{Export: m.Source, MountPoint: m.MountPoint, FSType: m.FSType}
+			if i, ok := index[m.MountPoint]; ok {
+				ret[i] = stat
+				cur = &ret[i]
+				continue
+			}
+			index[m.MountPoint] = len(ret)
+			ret = append(ret, stat)
+			cur = &ret[len(ret)-1]
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch {
+		case fields[0] == "age:" && len(fields) > 1:
+			cur.AgeSeconds, _ = strconv.ParseUint(fields[1], 10, 64)
+		case fields[0] == "per-op":
+			inOps = true
+		case inOps && strings.HasSuffix(fields[0], ":"):
+			if op, ok := parseNFSOp(fields); ok && op.Ops > 0 {
+				cur.Ops = append(cur.Ops, op)
+			}
+		}
+	}
+	return ret, nil
+}
+
+// parseNFSOp 解析 "READ: ops trans timeouts bytes_sent bytes_recv queue_ms rtt_ms execute_ms [errors]"
+func parseNFSOp(fields []string) (model.NFSOpStat, bool) {
+	if len(fields) < 9 {
+		return model.NFSOpStat{}, false
+	}
+	values := make([]uint64, len(fields)-1)
+	for i, f := range fields[1:] {
+		v, err := strconv.ParseUint(f, 10, 64)
+		if err != nil {
+			return model.NFSOpStat{}, false
+		}
+		values[i] = v
+	}
+	op := model.NFSOpStat{
+		Op:            strings.TrimSuffix(fields[0], ":"),
+		Ops:           values[0],
+		Transmissions: values[1],
+		MajorTimeouts: values[2],
+		BytesSent:     values[3],
+		BytesRecv:     values[4],
+		QueueMs:       values[5],
+		RTTMs:         values[6],
+		ExecuteMs:     values[7],
+	}
+	// statvers 1.1 之后的内核追加了错误数
+	if len(values) > 8 {
+		op.Errors = values[8]
+	}
+	op.Retransmissions = op.Transmissions - min(op.Ops, op.Transmissions)
+	return op, true
+}
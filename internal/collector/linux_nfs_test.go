@@ -0,0 +1,37 @@
+package collector
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"tisminSRETool/internal/model"
+)
+
+func TestCollectNFS(t *testing.T) {
+	c := &LinuxCollector{procRoot: "testdata/proc"}
+	got, err := c.CollectNFS(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []model.NFSMountStat{
+		// /mnt/data 被重复挂载，只保留最后一次可见的 srv2:/data
+		{
+			Export: "srv2:/data", MountPoint: "/mnt/data", FSType: "nfs4", AgeSeconds: 3600,
+			Ops: []model.NFSOpStat{
+				{Op: "READ", Ops: 1000, Transmissions: 1003, Retransmissions: 3, MajorTimeouts: 1, BytesSent: 160000, BytesRecv: 131072000, QueueMs: 500, RTTMs: 8000, ExecuteMs: 9000, Errors: 2},
+				{Op: "WRITE", Ops: 200, Transmissions: 200, BytesSent: 26214400, BytesRecv: 32000, QueueMs: 100, RTTMs: 3000, ExecuteMs: 3500},
+				{Op: "GETATTR", Ops: 5000, Transmissions: 5000, BytesSent: 800000, BytesRecv: 1200000, QueueMs: 50, RTTMs: 2500, ExecuteMs: 2800},
+			},
+		},
+		// 挂载点中的空格转义为 \040；旧内核没有错误数列
+		{
+			Export: "nas:/share", MountPoint: "/mnt/my share", FSType: "nfs", AgeSeconds: 60,
+			Ops: []model.NFSOpStat{
+				{Op: "GETATTR", Ops: 10, Transmissions: 12, Retransmissions: 2, BytesSent: 1600, BytesRecv: 2400, QueueMs: 1, RTTMs: 20, ExecuteMs: 25},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectNFS mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
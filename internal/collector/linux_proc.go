@@ -2,8 +2,11 @@ package collector
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math"
+	"math/bits"
 	"strconv"
 	"strings"
 	"sync"
@@ -14,33 +17,6 @@ import (
 	"golang.org/x/sys/unix"
 )
 
-// CPU信息收集核心逻辑
-func collectCPUCores(ctx context.Context) (cores int, err error) {
-	lines, err := utils.ReadLinesOffsetNWithContext(ctx, "/proc/cpuinfo", 0, -1)
-	if err != nil {
-		return 0, err
-	}
-
-	cpuCores := 0
-	for _, line := range lines {
-		if err := ctx.Err(); err != nil {
-			return 0, err
-		}
-		field := strings.SplitN(line, ":", 2)
-		if len(field) != 2 {
-			continue
-		}
-		key := strings.TrimSpace(field[0])
-		if key == "processor" {
-			cpuCores++
-		}
-	}
-	if cpuCores == 0 {
-		return 0, fmt.Errorf("no processor entries found in /proc/cpuinfo")
-	}
-	return cpuCores, nil
-}
-
 // CollectCPUUsage is deprecated in favor of CollectCPUStat.
 //func CollectCPUUsage(ctx context.Context, cores int) (usage float64, err error) {
 //	var total float64
@@ -58,92 +34,104 @@ func collectCPUCores(ctx context.Context) (cores int, err error) {
 //}
 
 // 整合CPU逻辑
-func CollectCPUStat(ctx context.Context) (model.CPUStat, error) {
-	cores, err := collectCPUCores(ctx)
-	if err != nil {
+func (c *LinuxCollector) CollectCPUStat(ctx context.Context) (model.CPUStat, error) {
+	stat := model.CPUStat{}
+	if err := c.collectCPUInfo(ctx, &stat); err != nil {
 		return model.CPUStat{}, err
 	}
-
-	perCPU, totalTicks, idleTicks, err := collectCPUInfo(ctx, cores)
-	if err != nil {
-		return model.CPUStat{}, err
-	}
-	if len(perCPU) > 0 {
-		cores = len(perCPU)
-	}
+	stat.Cores = len(stat.PerCPUUsage)
 
 	avg := 0.0
-	for _, v := range perCPU {
+	for _, v := range stat.PerCPUUsage {
 		avg += v
 	}
-	if len(perCPU) > 0 {
-		avg /= float64(len(perCPU))
+	if len(stat.PerCPUUsage) > 0 {
+		avg /= float64(len(stat.PerCPUUsage))
 	}
+	stat.UsagePercent = avg
 
-	loads, err := collectLoadAvg(ctx)
+	loads, err := c.collectLoadAvg(ctx)
 	if err != nil {
 		return model.CPUStat{}, err
 	}
 	if len(loads) < 3 {
 		return model.CPUStat{}, fmt.Errorf("invalid loadavg length: %d", len(loads))
 	}
-
-	return model.CPUStat{
-		Cores:        cores,
-		UsagePercent: avg,
-		PerCPUUsage:  perCPU,
-		Load1:        loads[0],
-		Load5:        loads[1],
-		Load15:       loads[2],
-		TotalTicks:   totalTicks,
-		IdleTicks:    idleTicks,
-	}, nil
+	stat.Load1 = loads[0]
+	stat.Load5 = loads[1]
+	stat.Load15 = loads[2]
+	return stat, nil
 }
 
-const cpuSampleWindow = 200 * time.Millisecond
+// /proc/stat 中 cpu 行各列的下标：user nice system idle iowait irq softirq steal guest guest_nice
+const (
+	cpuModeUser = iota
+	cpuModeNice
+	cpuModeSystem
+	cpuModeIdle
+	cpuModeIOWait
+	cpuModeIRQ
+	cpuModeSoftIRQ
+	cpuModeSteal
+	// guest/guest_nice 已计入 user/nice，不参与总量计算
+	cpuModeCount
+)
 
 type cpuSnapshot struct {
+	name  string // cpu0、cpu1 ...，整体为 cpu
 	total float64
 	idle  float64
+	modes [cpuModeCount]float64
 }
 
-func collectCPUInfo(ctx context.Context, cores int) (perCPU []float64, totalTicks uint64, idleTicks uint64, err error) {
-	_, startPerCPU, err := readCPUSnapshots(ctx, cores)
-	if err != nil {
-		return nil, 0, 0, err
-	}
+// cpuState 保存上一轮 /proc/stat 快照，使用率按两次采集之间的完整间隔计算
+type cpuState struct {
+	mu         sync.Mutex
+	prevAll    cpuSnapshot
+	prevPerCPU map[string]cpuSnapshot
+}
+
+// swap 记录本轮快照并返回上一轮快照
+// 首次采集时上一轮为零值，得到的是开机以来的平均使用率
+func (s *cpuState) swap(overall cpuSnapshot, perCPU []cpuSnapshot) (cpuSnapshot, map[string]cpuSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	timer := time.NewTimer(cpuSampleWindow)
-	defer timer.Stop()
-	select {
-	case <-ctx.Done():
-		return nil, 0, 0, ctx.Err()
-	case <-timer.C:
+	prevAll, prevPerCPU := s.prevAll, s.prevPerCPU
+	s.prevAll = overall
+	s.prevPerCPU = make(map[string]cpuSnapshot, len(perCPU))
+	for _, snapshot := range perCPU {
+		s.prevPerCPU[snapshot.name] = snapshot
 	}
+	return prevAll, prevPerCPU
+}
 
-	endOverall, endPerCPU, err := readCPUSnapshots(ctx, cores)
+func (c *LinuxCollector) collectCPUInfo(ctx context.Context, stat *model.CPUStat) error {
+	endOverall, endPerCPU, err := c.readCPUSnapshots(ctx)
 	if err != nil {
-		return nil, 0, 0, err
+		return err
 	}
+	startOverall, startPerCPU := c.cpu.swap(endOverall, endPerCPU)
+
 	if endOverall.total > 0 {
-		totalTicks = uint64(endOverall.total)
+		stat.TotalTicks = uint64(endOverall.total)
 	}
 	if endOverall.idle > 0 {
-		idleTicks = uint64(endOverall.idle)
-	}
-
-	n := len(startPerCPU)
-	if len(endPerCPU) < n {
-		n = len(endPerCPU)
+		stat.IdleTicks = uint64(endOverall.idle)
 	}
-	if n == 0 {
-		return nil, totalTicks, idleTicks, fmt.Errorf("no cpu core stats found in /proc/stat")
+	if modes, ok := cpuModePercents(startOverall, endOverall); ok {
+		stat.Modes = modes
 	}
 
-	perCPUUsage := make([]float64, 0, n)
+	n := len(endPerCPU)
+	perCPUUsage := make([]float64, n)
+	perCPUModes := make([]model.CPUModes, n)
 	for i := 0; i < n; i++ {
-		diffTotal := endPerCPU[i].total - startPerCPU[i].total
-		diffIdle := endPerCPU[i].idle - startPerCPU[i].idle
+		// 按核心名匹配上一轮快照，CPU 热插拔新上线的核心按开机以来计算
+		start := startPerCPU[endPerCPU[i].name]
+		diffTotal := endPerCPU[i].total - start.total
+		diffIdle := endPerCPU[i].idle - start.idle
+		// 计数器回退时本轮记为 0
 		if diffTotal <= 0 {
 			continue
 		}
@@ -154,16 +142,41 @@ func collectCPUInfo(ctx context.Context, cores int) (perCPU []float64, totalTick
 		if usage > 100 {
 			usage = 100
 		}
-		perCPUUsage = append(perCPUUsage, usage)
-	}
-	if len(perCPUUsage) == 0 {
-		return nil, totalTicks, idleTicks, fmt.Errorf("failed to calculate cpu usage from /proc/stat")
+		perCPUUsage[i] = usage
+		perCPUModes[i], _ = cpuModePercents(start, endPerCPU[i])
 	}
-	return perCPUUsage, totalTicks, idleTicks, nil
+	stat.PerCPUUsage = perCPUUsage
+	stat.PerCPUModes = perCPUModes
+	return nil
+}
+
+// cpuModePercents 计算两次快照之间各模式时间占比（百分制）
+func cpuModePercents(start, end cpuSnapshot) (model.CPUModes, bool) {
+	diffTotal := end.total - start.total
+	if diffTotal <= 0 {
+		return model.CPUModes{}, false
+	}
+	pct := func(mode int) float64 {
+		diff := end.modes[mode] - start.modes[mode]
+		if diff <= 0 {
+			return 0
+		}
+		return diff / diffTotal * 100
+	}
+	return model.CPUModes{
+		User:    pct(cpuModeUser),
+		Nice:    pct(cpuModeNice),
+		System:  pct(cpuModeSystem),
+		Idle:    pct(cpuModeIdle),
+		IOWait:  pct(cpuModeIOWait),
+		IRQ:     pct(cpuModeIRQ),
+		SoftIRQ: pct(cpuModeSoftIRQ),
+		Steal:   pct(cpuModeSteal),
+	}, true
 }
 
-func readCPUSnapshots(ctx context.Context, cores int) (overall cpuSnapshot, perCPU []cpuSnapshot, err error) {
-	lines, err := utils.ReadLinesOffsetNWithContext(ctx, "/proc/stat", 0, -1)
+func (c *LinuxCollector) readCPUSnapshots(ctx context.Context) (overall cpuSnapshot, perCPU []cpuSnapshot, err error) {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("stat"), 0, -1)
 	if err != nil {
 		return cpuSnapshot{}, nil, err
 	}
@@ -187,16 +200,13 @@ func readCPUSnapshots(ctx context.Context, cores int) (overall cpuSnapshot, perC
 			log.Printf("invalid format of /proc/stat: %s", line)
 			continue
 		}
+		snapshot.name = fields[0]
 
 		if fields[0] == "cpu" {
 			overall = snapshot
 			continue
 		}
 		perCPU = append(perCPU, snapshot)
-
-		if cores > 0 && len(perCPU) >= cores {
-			break
-		}
 	}
 	if len(perCPU) == 0 {
 		return cpuSnapshot{}, nil, fmt.Errorf("no cpu core stats found in /proc/stat")
@@ -209,26 +219,29 @@ func parseCPUSnapshot(fields []string) (cpuSnapshot, bool) {
 		return cpuSnapshot{}, false
 	}
 
-	total := 0.0
-	idle := 0.0
+	var snapshot cpuSnapshot
 	for i, raw := range fields {
+		if i >= cpuModeCount {
+			break
+		}
 		val, err := strconv.ParseFloat(raw, 64)
 		if err != nil {
 			return cpuSnapshot{}, false
 		}
-		total += val
-		if i == 3 || i == 4 {
-			idle += val
+		snapshot.modes[i] = val
+		snapshot.total += val
+		if i == cpuModeIdle || i == cpuModeIOWait {
+			snapshot.idle += val
 		}
 	}
-	if total <= 0 {
+	if snapshot.total <= 0 {
 		return cpuSnapshot{}, false
 	}
-	return cpuSnapshot{total: total, idle: idle}, true
+	return snapshot, true
 }
 
-func collectLoadAvg(ctx context.Context) ([]float64, error) {
-	lines, err := utils.ReadLinesOffsetNWithContext(ctx, "/proc/loadavg", 0, -1)
+func (c *LinuxCollector) collectLoadAvg(ctx context.Context) ([]float64, error) {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("loadavg"), 0, -1)
 	if err != nil {
 		return nil, err
 	}
@@ -254,8 +267,8 @@ func collectLoadAvg(ctx context.Context) ([]float64, error) {
 	return []float64{load1, load5, load15}, nil
 }
 
-func CollectMeminfo(ctx context.Context) (m *model.MemoryStat, err error) {
-	lines, err := utils.ReadLinesOffsetNWithContext(ctx, "/proc/meminfo", 0, -1)
+func (c *LinuxCollector) CollectMeminfo(ctx context.Context) (m *model.MemoryStat, err error) {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("meminfo"), 0, -1)
 	if err != nil {
 		log.Printf("error collecting meminfo: %s", err)
 		return nil, err
@@ -265,6 +278,25 @@ func CollectMeminfo(ctx context.Context) (m *model.MemoryStat, err error) {
 	var buffer uint64
 	var cache uint64
 	var available uint64
+	// 其余明细字段，单位为 kB 的换算为字节，HugePages_* 为页数
+	details := map[string]*uint64{
+		"Dirty":           &ret.Dirty,
+		"Writeback":       &ret.Writeback,
+		"Mapped":          &ret.Mapped,
+		"Shmem":           &ret.Shmem,
+		"Slab":            &ret.Slab,
+		"SReclaimable":    &ret.SReclaimable,
+		"SUnreclaim":      &ret.SUnreclaim,
+		"PageTables":      &ret.PageTables,
+		"CommitLimit":     &ret.CommitLimit,
+		"Committed_AS":    &ret.CommittedAS,
+		"AnonHugePages":   &ret.AnonHugePages,
+		"HugePages_Total": &ret.HugePagesTotal,
+		"HugePages_Free":  &ret.HugePagesFree,
+		"HugePages_Rsvd":  &ret.HugePagesRsvd,
+		"HugePages_Surp":  &ret.HugePagesSurp,
+		"Hugepagesize":    &ret.HugePageSize,
+	}
 	for _, line := range lines {
 		if err := ctx.Err(); err != nil {
 			return nil, err
@@ -330,10 +362,24 @@ func CollectMeminfo(ctx context.Context) (m *model.MemoryStat, err error) {
 			}
 			cache = t * 1024
 		default:
-			continue
+			field, ok := details[key]
+			if !ok {
+				continue
+			}
+			t, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				log.Printf("error collecting %s: %s", key, err)
+				return ret, err
+			}
+			if len(valueFields) > 1 && valueFields[1] == "kB" {
+				t *= 1024
+			}
+			*field = t
 		}
 	}
 	ret.Available = available
+	ret.Buffers = buffer
+	ret.Cached = cache
 	if available > 0 {
 		ret.Used = ret.Total - available
 	} else {
@@ -351,99 +397,68 @@ func CollectMeminfo(ctx context.Context) (m *model.MemoryStat, err error) {
 	return ret, nil
 }
 
-// 读取 /proc/mounts 获取真实挂载点（过滤虚拟文件系统）
-func readMounts(ctx context.Context) (map[string]string, error) {
-	lines, err := utils.ReadLinesOffsetNWithContext(ctx, "/proc/mounts", 0, -1)
-	if err != nil {
-		return nil, err
-	}
-	// device -> mountPoint 映射
-	mounts := make(map[string]string)
-	for _, line := range lines {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-		fields := strings.Fields(line)
-		if len(fields) < 3 {
-			continue
-		}
-		device := fields[0]
-		mountPoint := fields[1]
-		fsType := fields[2]
-
-		// 过滤虚拟文件系统
-		if isVirtualFS(fsType) {
-			continue
-		}
-
-		// 转换设备名（如 /dev/sda -> sda）
-		deviceName := strings.TrimPrefix(device, "/dev/")
-		mounts[deviceName] = mountPoint
+// 判断是否为网络文件系统，此类挂载没有对应的块设备，只采集容量
+func isNetworkFS(fsType string) bool {
+	switch fsType {
+	case "nfs", "nfs4", "cifs", "smb3", "smbfs", "ceph", "glusterfs", "fuse.glusterfs", "fuse.sshfs", "9p":
+		return true
 	}
-	return mounts, nil
-}
-
-// 判断是否为虚拟文件系统
-func isVirtualFS(fsType string) bool {
-	virtualFSTypes := map[string]bool{
-		"tmpfs":      true,
-		"devtmpfs":   true,
-		"overlay":    true,
-		"aufs":       true,
-		"devpts":     true,
-		"sysfs":      true,
-		"proc":       true,
-		"cgroup":     true,
-		"cgroup2":    true,
-		"securityfs": true,
-		"pstore":     true,
-		"efivarfs":   true,
-		"bpf":        true,
-		"tracefs":    true,
-		"hugetlbfs":  true,
-		"mqueue":     true,
-		"fusectl":    true,
-		"configfs":   true,
-		"debugfs":    true,
-		"selinuxfs":  true,
-	}
-	return virtualFSTypes[fsType]
+	return false
 }
 
-// 2) statfs 取容量
-func statFS(path string) (total, free, avail, inodes, inodesFree uint64, err error) {
+// 2) statfs 取容量，挂载点路径基于 rootFS 解析（容器内挂载宿主机根目录时使用）
+// 该调用在挂载点失联时可能永久阻塞，需通过 startStatFS 异步调用
+func (c *LinuxCollector) statFS(mountPoint string) statfsResult {
 	var st unix.Statfs_t
-	if err = unix.Statfs(path, &st); err != nil {
-		return
-	}
-	total = st.Blocks * uint64(st.Bsize)
-	free = st.Bfree * uint64(st.Bsize)
-	avail = st.Bavail * uint64(st.Bsize)
-	inodes = st.Files
-	inodesFree = st.Ffree
-	return
+	if err := unix.Statfs(c.rootPath(mountPoint), &st); err != nil {
+		return statfsResult{err: err}
+	}
+	return statfsResult{
+		total:      st.Blocks * uint64(st.Bsize),
+		free:       st.Bfree * uint64(st.Bsize),
+		avail:      st.Bavail * uint64(st.Bsize),
+		inodes:     st.Files,
+		inodesFree: st.Ffree,
+	}
 }
 
-// 3) 读取 /proc/diskstats (IO 计数)，只保留物理磁盘
+// 3) 读取 /proc/diskstats (IO 计数)
+// 各列含义见内核文档 Documentation/admin-guide/iostats.rst
 type DiskIOStat struct {
 	Name         string
-	ReadIOs      uint64 // 读 I/O 次数
+	ReadIOs      uint64 // 读完成次数
 	ReadSectors  uint64 // 读扇区数
-	WriteIOs     uint64 // 写 I/O 次数
+	ReadTicks    uint64 // 读耗时 ms
+	WriteIOs     uint64 // 写完成次数
 	WriteSectors uint64 // 写扇区数
-	IOQueuesTime uint64 // I/O等待时间 ms
+	WriteTicks   uint64 // 写耗时 ms
+	InFlight     uint64 // 当前正在处理的 I/O 数
+	IOTicks      uint64 // 设备处于忙碌状态的时间 ms，用于计算 %util
+	TimeInQueue  uint64 // 加权 I/O 耗时 ms，用于计算 aqu-sz
 }
 
-var (
-	diskStateMu    sync.Mutex
-	prevDiskStats  map[string]DiskIOStat
-	prevDiskStatAt time.Time
-)
+// diskState 保存上一轮 /proc/diskstats，每个 LinuxCollector 实例独立维护
+type diskState struct {
+	mu     sync.Mutex
+	prev   map[string]DiskIOStat
+	prevAt time.Time
+}
 
+// diskRates 两次采集之间的 iostat 等价指标
+type diskRates struct {
+	readIOPS, writeIOPS   float64
+	readBytes, writeBytes float64 // Bytes/s
+	readAwait, writeAwait float64 // ms
+	await                 float64 // ms
+	avgQueueSize          float64
+	util                  float64 // %
+}
+
+// /proc/diskstats 扇区固定为 512 字节，与设备实际扇区大小无关
 const sectorSizeBytes uint64 = 512
 
-func readDiskStats(ctx context.Context) (map[string]DiskIOStat, error) {
-	lines, err := utils.ReadLinesOffsetNWithContext(ctx, "/proc/diskstats", 0, -1)
+func (c *LinuxCollector) readDiskStats(ctx context.Context) (map[string]DiskIOStat, error) {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("diskstats"), 0, -1)
 	if err != nil {
 		return nil, err
 	}
@@ -456,152 +471,218 @@ func readDiskStats(ctx context.Context) (map[string]DiskIOStat, error) {
 		if len(fields) < 14 {
 			continue
 		}
+		// 设备过滤随挂载点一起在 CollectDisk 中进行，include 规则保留的 loop/ram 设备上的挂载点也能拿到 IO 统计
 		name := strings.TrimSpace(fields[2])
 
-		// 过滤虚拟设备和分区
-		if strings.HasPrefix(name, "loop") || strings.HasPrefix(name, "ram") {
-			continue
+		var values [11]uint64
+		for i := range values {
+			values[i], _ = strconv.ParseUint(fields[3+i], 10, 64)
 		}
-		// 如果是分区（如 sda1, nvme0n1p1），只保留整盘
-		//if isPartition(name) {
-		//	continue
-		//}
-
-		readIO, _ := strconv.ParseUint(fields[3], 10, 64)
-		readSectors, _ := strconv.ParseUint(fields[5], 10, 64)
-		writeIO, _ := strconv.ParseUint(fields[7], 10, 64)
-		writeSectors, _ := strconv.ParseUint(fields[9], 10, 64)
-		ioQueuesTime, _ := strconv.ParseUint(fields[12], 10, 64)
-
 		stats[name] = DiskIOStat{
 			Name:         name,
-			ReadIOs:      readIO,
-			ReadSectors:  readSectors,
-			WriteIOs:     writeIO,
-			WriteSectors: writeSectors,
-			IOQueuesTime: ioQueuesTime,
+			ReadIOs:      values[0],
+			ReadSectors:  values[2],
+			ReadTicks:    values[3],
+			WriteIOs:     values[4],
+			WriteSectors: values[6],
+			WriteTicks:   values[7],
+			InFlight:     values[8],
+			IOTicks:      values[9],
+			TimeInQueue:  values[10],
 		}
 	}
 	return stats, nil
 }
 
-// 判断是否为分区
-// sda, nvme0n1 -> false (整盘)
-// sda1, nvme0n1p1 -> true (分区)
-/*func isPartition(name string) bool {
-	// NVMe 设备: nvme0n1p1
-	if strings.HasPrefix(name, "nvme") && strings.Contains(name, "p") {
-		return true
-	}
-	// SCSI/SATA 设备: sda1, sdb2
-	if len(name) > 3 {
-		_, err := strconv.Atoi(name[3:])
-		return err == nil
-	}
-	return false
-}*/
-
-// CollectDisk 组合 DiskStat 从物理磁盘出发，查找挂载点，正确匹配 IO 统计
-func CollectDisk(ctx context.Context) ([]model.DiskStat, error) {
-	// 获取设备名 -> 挂载点 映射
-	mounts, err := readMounts(ctx)
+// CollectDisk 从挂载表出发，通过块设备拓扑找到挂载点对应的设备，再匹配 IO 统计
+// 同一设备的多个挂载点（bind 挂载、btrfs 子卷）各自输出一条记录
+func (c *LinuxCollector) CollectDisk(ctx context.Context) ([]model.DiskStat, error) {
+	mounts, err := c.readMountInfo(ctx)
 	if err != nil {
 		return nil, err
 	}
+	topo := c.readBlockTopology()
 
-	// 获取物理磁盘 IO 统计
-	ioStats, err := readDiskStats(ctx)
+	// 获取块设备 IO 统计
+	ioStats, err := c.readDiskStats(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	now := time.Now()
-	prevStats, elapsedSec := snapshotPrevDiskStats(ioStats, now)
+	prevStats, elapsedSec := c.disk.swap(ioStats, now)
+
+	// 同一挂载点被重复挂载时只有最后一次可见
+	visible := make(map[string]int, len(mounts))
+	for i, m := range mounts {
+		visible[m.MountPoint] = i
+	}
+
+	// 先为所有挂载点并发发起 statfs 再逐个收集结果，各挂载点单独计算超时，单个卡死的挂载点不会拖慢其他挂载点
+	type diskMount struct {
+		mountEntry
+		dev    *blockDevice
+		result *statfsCall
+	}
+	var candidates []diskMount
+	var filtered []string
+	for i, m := range mounts {
+		if visible[m.MountPoint] != i {
+			continue
+		}
+		dev := topo.resolve(m, c.rootPath)
+		devName := ""
+		if dev != nil {
+			devName = dev.Name
+		}
+		keep, forced, reason := c.diskFilter.keepMount(m, devName)
+		if !keep {
+			filtered = append(filtered, m.MountPoint+" ("+reason+")")
+			continue
+		}
+		// 没有块设备的挂载点只保留网络文件系统和显式 include 的（如 /dev/shm 上的 tmpfs）
+		if dev == nil && !forced && !isNetworkFS(m.FSType) {
+			filtered = append(filtered, m.MountPoint+" (no block device)")
+			continue
+		}
+		candidates = append(candidates, diskMount{mountEntry: m, dev: dev, result: c.startStatFS(m.MountPoint)})
+	}
+	if c.debug {
+		c.diskFilter.logFiltered(filtered)
+	}
 
 	var out []model.DiskStat
-	// 遍历物理磁盘
-	for deviceName, ioStat := range ioStats {
+	for _, m := range candidates {
 		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
 
-		// 查找该物理磁盘挂载点
-		mountPoint, ok := mounts[deviceName]
-		if !ok {
-			// 物理磁盘没有挂载点（如未使用的磁盘），跳过
-			continue
+		stat := model.DiskStat{
+			MountPoint: m.MountPoint,
+			Source:     m.Source,
+			FSType:     m.FSType,
+			FSRoot:     m.Root,
+			Status:     model.DiskStatusOK,
+		}
+		if m.dev != nil {
+			stat.Device = m.dev.Name
+			stat.DMName = m.dev.DMName
+			if m.dev.Parent != "" {
+				stat.Partition = m.dev.Name
+			}
+			stat.ParentDisk = strings.Join(topo.parentDisks(m.dev), ",")
 		}
 
 		// 获取容量信息
-		total, free, _, inodes, inodesFree, err := statFS(mountPoint)
-		if err != nil {
+		res := m.result.wait(ctx)
+		if errors.Is(res.err, errStaleMount) {
+			stat.Status = model.DiskStatusStale
+			out = append(out, stat)
 			continue
 		}
-		used := total - free
-		usedPct := 0.0
-		if total > 0 {
-			usedPct = float64(used) / float64(total) * 100
-		}
-
-		readBytes := ioStat.ReadSectors * sectorSizeBytes
-		writeBytes := ioStat.WriteSectors * sectorSizeBytes
-
-		await := 0.0
-		util := 0.0
-		if elapsedSec > 0 && prevStats != nil {
-			if prev, ok := prevStats[deviceName]; ok {
-				diffReadIO := uint64Diff(ioStat.ReadIOs, prev.ReadIOs)
-				diffWriteIO := uint64Diff(ioStat.WriteIOs, prev.WriteIOs)
-				diffIOs := diffReadIO + diffWriteIO
-				diffQueueTime := uint64Diff(ioStat.IOQueuesTime, prev.IOQueuesTime)
-
-				if diffIOs > 0 {
-					await = float64(diffQueueTime) / float64(diffIOs)
-				}
-				util = float64(diffQueueTime) / (elapsedSec * 1000) * 100
-				if util < 0 {
-					util = 0
-				}
-			}
+		if res.err != nil {
+			continue
 		}
-
-		out = append(out, model.DiskStat{
-			MountPoint:        mountPoint,
-			Device:            deviceName,
-			Total:             total,
-			Free:              free,
-			Used:              used,
-			UsedPercent:       usedPct,
-			InodesTotal:       inodes,
-			InodesFree:        inodesFree,
-			InodesUsed:        inodes - inodesFree,
-			InodesUsedPercent: utils.Pct(inodes-inodesFree, inodes),
-			Read:              readBytes,
-			ReadSectors:       ioStat.ReadSectors,
-			Write:             writeBytes,
-			WriteSectors:      ioStat.WriteSectors,
-			Await:             await,
-			Util:              util,
-			IOQueueTime:       ioStat.IOQueuesTime,
-		})
+		stat.Total = res.total
+		stat.Free = res.free
+		stat.Used = res.total - res.free
+		stat.UsedPercent = utils.Pct(stat.Used, stat.Total)
+		stat.InodesTotal = res.inodes
+		stat.InodesFree = res.inodesFree
+		stat.InodesUsed = res.inodes - res.inodesFree
+		stat.InodesUsedPercent = utils.Pct(stat.InodesUsed, stat.InodesTotal)
+
+		if ioStat, ok := ioStats[stat.Device]; ok {
+			// 新出现的设备没有上一轮数据，本轮速率为 0
+			var rates diskRates
+			if prev, ok := prevStats[stat.Device]; ok && elapsedSec > 0 {
+				rates = calcDiskRates(prev, ioStat, elapsedSec)
+			}
+			stat.Read = ioStat.ReadSectors * sectorSizeBytes
+			stat.ReadIOs = ioStat.ReadIOs
+			stat.ReadSectors = ioStat.ReadSectors
+			stat.ReadSpeed = rates.readBytes
+			stat.Write = ioStat.WriteSectors * sectorSizeBytes
+			stat.WriteIOs = ioStat.WriteIOs
+			stat.WriteSectors = ioStat.WriteSectors
+			stat.WriteSpeed = rates.writeBytes
+			stat.ReadIOPS = rates.readIOPS
+			stat.WriteIOPS = rates.writeIOPS
+			stat.ReadKBps = rates.readBytes / 1024
+			stat.WriteKBps = rates.writeBytes / 1024
+			stat.ReadAwait = rates.readAwait
+			stat.WriteAwait = rates.writeAwait
+			stat.Await = rates.await
+			stat.AvgQueueSize = rates.avgQueueSize
+			stat.Util = rates.util
+			stat.IOTicks = ioStat.IOTicks
+			stat.IOQueueTime = ioStat.TimeInQueue
+		}
+		out = append(out, stat)
 	}
 	return out, nil
 }
 
-func snapshotPrevDiskStats(current map[string]DiskIOStat, now time.Time) (map[string]DiskIOStat, float64) {
-	diskStateMu.Lock()
-	defer diskStateMu.Unlock()
+// swap 记录本轮 diskstats 并返回上一轮数据；已消失的设备随之从状态中移除
+func (s *diskState) swap(current map[string]DiskIOStat, now time.Time) (map[string]DiskIOStat, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	previous := prevDiskStats
+	previous := s.prev
 	elapsed := 0.0
-	if !prevDiskStatAt.IsZero() {
-		elapsed = now.Sub(prevDiskStatAt).Seconds()
+	if !s.prevAt.IsZero() {
+		elapsed = now.Sub(s.prevAt).Seconds()
 	}
-	prevDiskStats = current
-	prevDiskStatAt = now
+	s.prev = current
+	s.prevAt = now
 	return previous, elapsed
 }
 
+// calcDiskRates 按 iostat -x 的口径计算两次采样间的指标
+func calcDiskRates(prev, cur DiskIOStat, elapsedSec float64) diskRates {
+	elapsedMs := elapsedSec * 1000
+
+	readIOs := counterDiff(cur.ReadIOs, prev.ReadIOs)
+	writeIOs := counterDiff(cur.WriteIOs, prev.WriteIOs)
+	readTicks := counterDiff(cur.ReadTicks, prev.ReadTicks)
+	writeTicks := counterDiff(cur.WriteTicks, prev.WriteTicks)
+
+	r := diskRates{
+		readIOPS:     float64(readIOs) / elapsedSec,
+		writeIOPS:    float64(writeIOs) / elapsedSec,
+		readBytes:    float64(counterDiff(cur.ReadSectors, prev.ReadSectors)*sectorSizeBytes) / elapsedSec,
+		writeBytes:   float64(counterDiff(cur.WriteSectors, prev.WriteSectors)*sectorSizeBytes) / elapsedSec,
+		avgQueueSize: float64(counterDiff(cur.TimeInQueue, prev.TimeInQueue)) / elapsedMs,
+		util:         float64(counterDiff(cur.IOTicks, prev.IOTicks)) / elapsedMs * 100,
+	}
+	if readIOs > 0 {
+		r.readAwait = float64(readTicks) / float64(readIOs)
+	}
+	if writeIOs > 0 {
+		r.writeAwait = float64(writeTicks) / float64(writeIOs)
+	}
+	if readIOs+writeIOs > 0 {
+		r.await = float64(readTicks+writeTicks) / float64(readIOs+writeIOs)
+	}
+	// 采样间隔抖动可能让 io_ticks 略大于墙钟时间
+	if r.util > 100 {
+		r.util = 100
+	}
+	return r
+}
+
+// counterDiff 计算单调计数器的增量，回退视为设备被重新创建，增量取当前值
+// 只有 32 位平台上 diskstats 计数器为 32 位的 unsigned long，才按 2^32 回绕处理
+func counterDiff(cur, prev uint64) uint64 {
+	if cur >= prev {
+		return cur - prev
+	}
+	if bits.UintSize == 32 && prev <= math.MaxUint32 {
+		return cur + (math.MaxUint32 - prev) + 1
+	}
+	return cur
+}
+
 func uint64Diff(cur, prev uint64) uint64 {
 	if cur < prev {
 		return 0
@@ -609,9 +690,9 @@ func uint64Diff(cur, prev uint64) uint64 {
 	return cur - prev
 }
 
-func CollectNetinfo(ctx context.Context) ([]model.NetStat, error) {
+func (c *LinuxCollector) CollectNetinfo(ctx context.Context) ([]model.NetStat, error) {
 	m := make([]model.NetStat, 0)
-	lines, err := utils.ReadLinesOffsetNWithContext(ctx, "/proc/net/dev", 2, -1)
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("net", "dev"), 2, -1)
 	if err != nil {
 		log.Printf("error collecting net io: %s", err)
 		return nil, err
@@ -630,7 +711,7 @@ func CollectNetinfo(ctx context.Context) ([]model.NetStat, error) {
 		parts[1] = line[separation+1:]
 
 		interfaceName := strings.TrimSpace(parts[0])
-		if interfaceName == "" {
+		if interfaceName == "" || !c.netFilter.keep(interfaceName) {
 			continue
 		}
 
@@ -697,6 +778,7 @@ func CollectNetinfo(ctx context.Context) ([]model.NetStat, error) {
 			TxErrors:  sendErrors,
 			TxDropped: sendDrops,
 		}
+		c.fillNetInterfaceInfo(&netStat)
 		m = append(m, netStat)
 	}
 	return m, nil
@@ -0,0 +1,186 @@
+package collector
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"tisminSRETool/internal/model"
+)
+
+// testdata/proc 与 testdata/proc_next 是同一台 2 核机器间隔一个采集周期的两份快照
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func checkCPUModes(t *testing.T, name string, got, want model.CPUModes) {
+	t.Helper()
+	g := []float64{got.User, got.Nice, got.System, got.Idle, got.IOWait, got.IRQ, got.SoftIRQ, got.Steal}
+	w := []float64{want.User, want.Nice, want.System, want.Idle, want.IOWait, want.IRQ, want.SoftIRQ, want.Steal}
+	for i := range g {
+		if !approxEqual(g[i], w[i]) {
+			t.Errorf("%s modes = %+v; want %+v", name, got, want)
+			return
+		}
+	}
+}
+
+func TestCollectCPUStat(t *testing.T) {
+	c := &LinuxCollector{procRoot: "testdata/proc"}
+
+	// 首轮没有上一轮快照，按开机以来的累计值计算
+	stat, err := c.CollectCPUStat(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.Cores != 2 || !approxEqual(stat.UsagePercent, 20) {
+		t.Errorf("first round cores=%d usage=%v; want 2, 20", stat.Cores, stat.UsagePercent)
+	}
+	if stat.Load1 != 0.5 || stat.Load5 != 1.25 || stat.Load15 != 2 {
+		t.Errorf("load = %v %v %v; want 0.5 1.25 2", stat.Load1, stat.Load5, stat.Load15)
+	}
+	checkCPUModes(t, "first round", stat.Modes, model.CPUModes{User: 10, System: 5, Idle: 75, IOWait: 5, Steal: 5})
+
+	c.procRoot = "testdata/proc_next"
+	stat, err = c.CollectCPUStat(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.TotalTicks != 4000 || stat.IdleTicks != 3100 {
+		t.Errorf("ticks total=%d idle=%d; want 4000, 3100", stat.TotalTicks, stat.IdleTicks)
+	}
+	if !approxEqual(stat.UsagePercent, 25) {
+		t.Errorf("usage = %v; want 25", stat.UsagePercent)
+	}
+	wantPerCPU := []float64{35, 15}
+	for i, want := range wantPerCPU {
+		if i >= len(stat.PerCPUUsage) || !approxEqual(stat.PerCPUUsage[i], want) {
+			t.Fatalf("per-cpu usage = %v; want %v", stat.PerCPUUsage, wantPerCPU)
+		}
+	}
+	checkCPUModes(t, "overall", stat.Modes, model.CPUModes{User: 10, System: 5, Idle: 70, IOWait: 5, Steal: 10})
+	checkCPUModes(t, "cpu0", stat.PerCPUModes[0], model.CPUModes{User: 15, System: 5, Idle: 60, IOWait: 5, Steal: 15})
+	checkCPUModes(t, "cpu1", stat.PerCPUModes[1], model.CPUModes{User: 5, System: 5, Idle: 80, IOWait: 5, Steal: 5})
+}
+
+func TestCollectMeminfo(t *testing.T) {
+	c := &LinuxCollector{procRoot: "testdata/proc"}
+	got, err := c.CollectMeminfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	const kB = 1024
+	want := &model.MemoryStat{
+		Total:           16000000 * kB,
+		Free:            2000000 * kB,
+		Available:       8000000 * kB,
+		Used:            8000000 * kB,
+		UsedPercent:     50,
+		SwapTotal:       4000000 * kB,
+		SwapFree:        3000000 * kB,
+		SwapUsed:        1000000 * kB,
+		SwapUsedPercent: 25,
+		Buffers:         500000 * kB,
+		Cached:          4000000 * kB,
+		Dirty:           1000 * kB,
+		Mapped:          300000 * kB,
+		Shmem:           200000 * kB,
+		Slab:            600000 * kB,
+		SReclaimable:    400000 * kB,
+		SUnreclaim:      200000 * kB,
+		PageTables:      50000 * kB,
+		CommitLimit:     12000000 * kB,
+		CommittedAS:     9000000 * kB,
+		AnonHugePages:   100000 * kB,
+		// HugePages_* 为页数，不带单位
+		HugePagesTotal: 16,
+		HugePagesFree:  8,
+		HugePagesRsvd:  2,
+		HugePageSize:   2048 * kB,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectMeminfo mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+// 3.14 之前的内核没有 MemAvailable，已用内存按 Total - Free - Buffers - Cached 计算
+func TestCollectMeminfoWithoutAvailable(t *testing.T) {
+	root := t.TempDir()
+	data := "MemTotal: 1000 kB\nMemFree: 100 kB\nBuffers: 200 kB\nCached: 300 kB\nSwapTotal: 0 kB\nSwapFree: 0 kB\n"
+	if err := os.WriteFile(filepath.Join(root, "meminfo"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := &LinuxCollector{procRoot: root}
+	got, err := c.CollectMeminfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Used != 400*1024 || got.UsedPercent != 40 || got.SwapUsedPercent != 0 {
+		t.Errorf("used=%d percent=%v swap percent=%v; want %d, 40, 0", got.Used, got.UsedPercent, got.SwapUsedPercent, 400*1024)
+	}
+}
+
+func TestReadDiskStats(t *testing.T) {
+	c := &LinuxCollector{procRoot: "testdata/proc"}
+	got, err := c.readDiskStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 列数不足的 ram0 跳过；loop 设备不在这里过滤，由挂载点规则决定
+	want := map[string]DiskIOStat{
+		"loop0": {Name: "loop0", ReadIOs: 50, ReadSectors: 400, ReadTicks: 10, IOTicks: 10, TimeInQueue: 10},
+		"sda":   {Name: "sda", ReadIOs: 1000, ReadSectors: 80000, ReadTicks: 2000, WriteIOs: 500, WriteSectors: 40000, WriteTicks: 3000, IOTicks: 4000, TimeInQueue: 5000},
+		"sda1":  {Name: "sda1", ReadIOs: 900, ReadSectors: 70000, ReadTicks: 1800, WriteIOs: 400, WriteSectors: 30000, WriteTicks: 2500, IOTicks: 3500, TimeInQueue: 4300},
+		"dm-0":  {Name: "dm-0", ReadIOs: 800, ReadSectors: 60000, ReadTicks: 1600, WriteIOs: 300, WriteSectors: 20000, WriteTicks: 1500, IOTicks: 3000, TimeInQueue: 3100},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readDiskStats mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestCalcDiskRates(t *testing.T) {
+	c := &LinuxCollector{procRoot: "testdata/proc"}
+	prev, err := c.readDiskStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.procRoot = "testdata/proc_next"
+	cur, err := c.readDiskStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		device string
+		want   diskRates
+	}{
+		{"sda", diskRates{
+			readIOPS: 10, writeIOPS: 30,
+			readBytes: 1048576, writeBytes: 524288,
+			readAwait: 5, writeAwait: 10, await: 8.75,
+			avgQueueSize: 2, util: 50,
+		}},
+		{"sda1", diskRates{}},
+		// dm-0 被重新创建，计数器回退后按当前值计算增量
+		{"dm-0", diskRates{
+			readIOPS: 5, writeIOPS: 1,
+			readBytes: 204800, writeBytes: 40960,
+			readAwait: 2, writeAwait: 2, await: 2,
+			avgQueueSize: 0.008, util: 0.6,
+		}},
+	}
+	for _, tt := range tests {
+		got := calcDiskRates(prev[tt.device], cur[tt.device], 10)
+		g := []float64{got.readIOPS, got.writeIOPS, got.readBytes, got.writeBytes, got.readAwait, got.writeAwait, got.await, got.avgQueueSize, got.util}
+		w := []float64{tt.want.readIOPS, tt.want.writeIOPS, tt.want.readBytes, tt.want.writeBytes, tt.want.readAwait, tt.want.writeAwait, tt.want.await, tt.want.avgQueueSize, tt.want.util}
+		for i := range g {
+			if !approxEqual(g[i], w[i]) {
+				t.Errorf("%s rates = %+v; want %+v", tt.device, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"tisminSRETool/internal/model"
+	"tisminSRETool/pkg/utils"
+)
+
+// Linux 用户态时钟频率（USER_HZ），/proc/[pid]/stat 中的 utime/stime 以此为单位
+const userHZ = 100
+
+const defaultProcessTopN = 10
+
+// 命令行最大保留长度，避免超长参数撑爆指标标签和 JSON 输出
+const maxCmdlineLen = 512
+
+// procSample 单个进程在某次采集时的原始数据
+type procSample struct {
+	pid       int
+	ppid      int
+	name      string
+	state     string
+	threads   int
+	ticks     uint64 // utime + stime
+	startTime uint64 // 进程启动时间，用于识别 PID 复用
+	rssBytes  uint64
+	fds       uint64
+	fdKnown   bool // 无权限读取 /proc/[pid]/fd 时为 false
+}
+
+// processState 保存上一轮采集的进程 CPU ticks，用于计算两次采集间的 CPU 使用率
+type processState struct {
+	mu     sync.Mutex
+	prev   map[int]procSample
+	prevAt time.Time
+
+	fdHistory map[int]*fdHistory
+	nofile    map[int]nofileLimit
+}
+
+// CollectProcesses 遍历 /proc/[pid]，返回 CPU、内存与 fd 占用最高的各 N 个进程
+// 未开启 fd 统计时 byFD 为空
+func (c *LinuxCollector) CollectProcesses(ctx context.Context) (byCPU, byMem, byFD []model.ProcStat, err error) {
+	entries, err := os.ReadDir(c.procPath())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	memTotal, err := c.readMemTotal()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pageSize := uint64(os.Getpagesize())
+
+	samples := make(map[int]procSample, len(entries))
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
+		pid, convErr := strconv.Atoi(entry.Name())
+		if convErr != nil || !entry.IsDir() {
+			continue
+		}
+		sample, readErr := c.readProcStat(pid, pageSize)
+		if readErr != nil {
+			// 进程在遍历过程中退出是正常现象，直接跳过
+			continue
+		}
+		if c.fd.Enabled {
+			if n, err := c.countFDs(pid); err == nil {
+				sample.fds = n
+				sample.fdKnown = true
+			}
+		}
+		samples[pid] = sample
+	}
+	if len(samples) == 0 {
+		return nil, nil, nil, fmt.Errorf("no process entries found in %s", c.procPath())
+	}
+
+	now := time.Now()
+	prev, elapsedSec := c.procs.swap(samples, now)
+	var fdGrowth map[int]int64
+	var fdGrowing, fdGrowthStart map[int]bool
+	var limits map[int]nofileLimit
+	if c.fd.Enabled {
+		window := c.fd.GrowthWindow
+		if window <= 0 {
+			window = defaultFDGrowthWindow
+		}
+		growthMin := c.fd.GrowthMin
+		if growthMin == 0 {
+			growthMin = defaultFDGrowthMin
+		}
+		fdGrowth, fdGrowing, fdGrowthStart = c.procs.trackFDs(samples, now, window, growthMin)
+		limits = c.cachedNOFILELimits(samples, now)
+	}
+
+	stats := make([]model.ProcStat, 0, len(samples))
+	for pid, cur := range samples {
+		cpu := 0.0
+		if p, ok := prev[pid]; ok && elapsedSec > 0 && p.startTime == cur.startTime {
+			cpu = float64(uint64Diff(cur.ticks, p.ticks)) / userHZ / elapsedSec * 100
+		}
+		stat := model.ProcStat{
+			PID:     pid,
+			PPID:    cur.ppid,
+			Name:    cur.name,
+			State:   cur.state,
+			Threads: cur.threads,
+			CPU:     cpu,
+			Mem:     utils.Pct(cur.rssBytes, memTotal),
+			RSS:     cur.rssBytes,
+		}
+		if cur.fdKnown {
+			stat.FDs = cur.fds
+			stat.FDGrowth = fdGrowth[pid]
+			stat.FDGrowing = fdGrowing[pid]
+			stat.FDGrowthStart = fdGrowthStart[pid]
+			if l, ok := limits[pid]; ok {
+				stat.FDSoftLimit = l.soft
+				stat.FDHardLimit = l.hard
+				stat.FDUsedPercent = utils.Pct(cur.fds, l.soft)
+			}
+		}
+		stats = append(stats, stat)
+	}
+
+	topN := c.processTopN
+	if topN <= 0 {
+		topN = defaultProcessTopN
+	}
+	users := c.readPasswd()
+
+	byCPU = topProcesses(stats, topN, func(a, b model.ProcStat) bool { return a.CPU > b.CPU })
+	byMem = topProcesses(stats, topN, func(a, b model.ProcStat) bool { return a.RSS > b.RSS })
+	if c.fd.Enabled {
+		byFD = topFDProcesses(stats, topN)
+		for i := range byFD {
+			c.refreshNOFILELimit(&byFD[i])
+			byFD[i].Sockets = c.countSockets(byFD[i].PID)
+		}
+	}
+	for _, list := range [][]model.ProcStat{byCPU, byMem, byFD} {
+		for i := range list {
+			c.fillProcDetails(&list[i], users)
+		}
+	}
+	return byCPU, byMem, byFD, nil
+}
+
+func (s *processState) swap(cur map[int]procSample, now time.Time) (map[int]procSample, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous := s.prev
+	elapsed := 0.0
+	if !s.prevAt.IsZero() {
+		elapsed = now.Sub(s.prevAt).Seconds()
+	}
+	s.prev = cur
+	s.prevAt = now
+	return previous, elapsed
+}
+
+func topProcesses(stats []model.ProcStat, n int, less func(a, b model.ProcStat) bool) []model.ProcStat {
+	sorted := make([]model.ProcStat, len(stats))
+	copy(sorted, stats)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if less(sorted[i], sorted[j]) {
+			return true
+		}
+		if less(sorted[j], sorted[i]) {
+			return false
+		}
+		return sorted[i].PID < sorted[j].PID
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// readProcStat 解析 /proc/[pid]/stat
+// 第二列 comm 用括号包裹且可能包含空格或括号，因此以最后一个 ')' 作为分界
+func (c *LinuxCollector) readProcStat(pid int, pageSize uint64) (procSample, error) {
+	data, err := os.ReadFile(c.procPath(strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return procSample{}, err
+	}
+	line := string(data)
+	start := strings.IndexByte(line, '(')
+	end := strings.LastIndexByte(line, ')')
+	if start < 0 || end < start {
+		return procSample{}, fmt.Errorf("invalid format of /proc/%d/stat", pid)
+	}
+	name := line[start+1 : end]
+
+	// fields[0] 对应 stat 中的第 3 列 state
+	fields := strings.Fields(line[end+1:])
+	if len(fields) < 22 {
+		return procSample{}, fmt.Errorf("invalid format of /proc/%d/stat", pid)
+	}
+	ppid, _ := strconv.Atoi(fields[1])
+	utime, _ := strconv.ParseUint(fields[11], 10, 64)
+	stime, _ := strconv.ParseUint(fields[12], 10, 64)
+	threads, _ := strconv.Atoi(fields[17])
+	startTime, _ := strconv.ParseUint(fields[19], 10, 64)
+	rssPages, _ := strconv.ParseUint(fields[21], 10, 64)
+
+	return procSample{
+		pid:       pid,
+		ppid:      ppid,
+		name:      name,
+		state:     fields[0],
+		threads:   threads,
+		ticks:     utime + stime,
+		startTime: startTime,
+		rssBytes:  rssPages * pageSize,
+	}, nil
+}
+
+// fillProcDetails 为入选 TopN 的进程补充属主和命令行
+func (c *LinuxCollector) fillProcDetails(p *model.ProcStat, users map[string]string) {
+	pidDir := strconv.Itoa(p.PID)
+
+	if lines, err := utils.ReadLines(c.procPath(pidDir, "status")); err == nil {
+		for _, line := range lines {
+			fields := strings.Fields(line)
+			if len(fields) < 2 || fields[0] != "Uid:" {
+				continue
+			}
+			p.User = fields[1]
+			if name, ok := users[fields[1]]; ok {
+				p.User = name
+			}
+			break
+		}
+	}
+
+	if data, err := os.ReadFile(c.procPath(pidDir, "cmdline")); err == nil {
+		data = bytes.TrimRight(data, "\x00")
+		if len(data) > maxCmdlineLen {
+			data = data[:maxCmdlineLen]
+		}
+		p.Cmdline = string(bytes.ReplaceAll(data, []byte{0}, []byte{' '}))
+	}
+}
+
+// readMemTotal 读取 MemTotal（Bytes），用于计算进程内存占比
+func (c *LinuxCollector) readMemTotal() (uint64, error) {
+	line, err := utils.ReadLine(c.procPath("meminfo"), "MemTotal:")
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("MemTotal not found in /proc/meminfo")
+	}
+	t, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return utils.KBtoByte(t), nil
+}
+
+// readPasswd 读取宿主机 /etc/passwd，返回 uid -> 用户名 映射
+func (c *LinuxCollector) readPasswd() map[string]string {
+	users := make(map[string]string)
+	lines, err := utils.ReadLines(c.rootPath("/etc/passwd"))
+	if err != nil {
+		return users
+	}
+	for _, line := range lines {
+		fields := strings.Split(line, ":")
+		if len(fields) < 3 {
+			continue
+		}
+		users[fields[2]] = fields[0]
+	}
+	return users
+}
@@ -0,0 +1,86 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"tisminSRETool/internal/model"
+	"tisminSRETool/pkg/utils"
+)
+
+// CollectPSI 读取 /proc/pressure/{cpu,memory,io}
+// 内核未开启 PSI（< 4.20 或 psi=0）时返回 Available=false 与错误，由调用方记录到 CollectErrors
+func (c *LinuxCollector) CollectPSI(ctx context.Context) (model.PSIStat, error) {
+	stat := model.PSIStat{}
+
+	resources := []struct {
+		name string
+		dst  *model.PSIResource
+	}{
+		{"cpu", &stat.CPU},
+		{"memory", &stat.Memory},
+		{"io", &stat.IO},
+	}
+	for _, res := range resources {
+		if err := ctx.Err(); err != nil {
+			return model.PSIStat{}, err
+		}
+		if err := c.readPressure(ctx, res.name, res.dst); err != nil {
+			if os.IsNotExist(err) {
+				return model.PSIStat{}, fmt.Errorf("pressure stall information not supported by kernel: %w", err)
+			}
+			return model.PSIStat{}, err
+		}
+	}
+	stat.Available = true
+	return stat, nil
+}
+
+// readPressure 解析单个 pressure 文件，格式如：
+//
+//	some avg10=1.63 avg60=1.64 avg300=2.17 total=26220464
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func (c *LinuxCollector) readPressure(ctx context.Context, resource string, dst *model.PSIResource) error {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("pressure", resource), 0, -1)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		var target *model.PSILine
+		switch fields[0] {
+		case "some":
+			target = &dst.Some
+		case "full":
+			target = &dst.Full
+		default:
+			continue
+		}
+		for _, kv := range fields[1:] {
+			key, value, ok := strings.Cut(kv, "=")
+			if !ok {
+				return fmt.Errorf("invalid format of /proc/pressure/%s: %s", resource, line)
+			}
+			var parseErr error
+			switch key {
+			case "avg10":
+				target.Avg10, parseErr = strconv.ParseFloat(value, 64)
+			case "avg60":
+				target.Avg60, parseErr = strconv.ParseFloat(value, 64)
+			case "avg300":
+				target.Avg300, parseErr = strconv.ParseFloat(value, 64)
+			case "total":
+				target.Total, parseErr = strconv.ParseUint(value, 10, 64)
+			}
+			if parseErr != nil {
+				return fmt.Errorf("invalid format of /proc/pressure/%s: %s", resource, line)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package collector
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"tisminSRETool/internal/model"
+)
+
+func TestCollectPSI(t *testing.T) {
+	c := &LinuxCollector{procRoot: "testdata/proc"}
+	got, err := c.CollectPSI(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := model.PSIStat{
+		Available: true,
+		CPU: model.PSIResource{
+			Some: model.PSILine{Avg10: 1.63, Avg60: 1.64, Avg300: 2.17, Total: 26220464},
+		},
+		Memory: model.PSIResource{
+			Some: model.PSILine{Avg10: 0.5, Avg60: 0.25, Avg300: 0.1, Total: 1500000},
+			Full: model.PSILine{Avg10: 0.2, Avg60: 0.1, Avg300: 0.05, Total: 700000},
+		},
+		IO: model.PSIResource{
+			Some: model.PSILine{Avg10: 12.5, Avg60: 8, Avg300: 3.2, Total: 98000000},
+			Full: model.PSILine{Avg10: 10, Avg60: 6.5, Avg300: 2.8, Total: 81000000},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectPSI mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+// 内核未开启 PSI 时没有 /proc/pressure
+func TestCollectPSIUnavailable(t *testing.T) {
+	c := &LinuxCollector{procRoot: t.TempDir()}
+	got, err := c.CollectPSI(context.Background())
+	if err == nil || got.Available {
+		t.Errorf("CollectPSI() = %+v, %v; want unavailable with error", got, err)
+	}
+}
@@ -0,0 +1,132 @@
+package collector
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"tisminSRETool/internal/model"
+)
+
+// CollectSensors 读取 /sys/class/hwmon 下的温度、风扇传感器和 /sys/class/thermal 下的温区
+// 虚拟机通常没有任何传感器，此时返回空列表而不是错误
+func (c *LinuxCollector) CollectSensors() ([]model.SensorStat, error) {
+	var ret []model.SensorStat
+	for _, dev := range readDirNames(c.sysPath("class", "hwmon")) {
+		ret = append(ret, c.readHwmon(dev)...)
+	}
+	for _, zone := range readDirNames(c.sysPath("class", "thermal")) {
+		if !strings.HasPrefix(zone, "thermal_zone") {
+			continue
+		}
+		if s, ok := c.readThermalZone(zone); ok {
+			ret = append(ret, s)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Device != ret[j].Device {
+			return ret[i].Device < ret[j].Device
+		}
+		return ret[i].Sensor < ret[j].Sensor
+	})
+	return ret, nil
+}
+
+// readHwmon 读取单个 hwmon 设备，温度单位为毫摄氏度，风扇为 RPM
+func (c *LinuxCollector) readHwmon(dev string) []model.SensorStat {
+	dir := c.sysPath("class", "hwmon", dev)
+	chip := readSysfsString(filepath.Join(dir, "name"))
+	// 旧内核的属性文件位于 device 子目录
+	if chip == "" {
+		if name := readSysfsString(filepath.Join(dir, "device", "name")); name != "" {
+			chip = name
+			dir = filepath.Join(dir, "device")
+		}
+	}
+
+	var ret []model.SensorStat
+	for _, file := range readDirNames(dir) {
+		sensor, ok := strings.CutSuffix(file, "_input")
+		if !ok {
+			continue
+		}
+		var sensorType string
+		var scale float64
+		switch {
+		case strings.HasPrefix(sensor, "temp"):
+			sensorType, scale = model.SensorTemperature, 1000
+		case strings.HasPrefix(sensor, "fan"):
+			sensorType, scale = model.SensorFan, 1
+		default:
+			continue
+		}
+		// 传感器离线时读取会返回 EIO/ENODATA
+		value, ok := readSysfsFloat(filepath.Join(dir, file), scale)
+		if !ok {
+			continue
+		}
+		s := model.SensorStat{
+			Chip:   chip,
+			Device: dev,
+			Sensor: sensor,
+			Label:  readSysfsString(filepath.Join(dir, sensor+"_label")),
+			Type:   sensorType,
+			Value:  value,
+		}
+		s.Max, _ = readSysfsFloat(filepath.Join(dir, sensor+"_max"), scale)
+		s.Crit, _ = readSysfsFloat(filepath.Join(dir, sensor+"_crit"), scale)
+		s.Min, _ = readSysfsFloat(filepath.Join(dir, sensor+"_min"), scale)
+		s.Alarm = readSysfsString(filepath.Join(dir, sensor+"_alarm")) == "1" ||
+			readSysfsString(filepath.Join(dir, sensor+"_crit_alarm")) == "1"
+		ret = append(ret, s)
+	}
+	return ret
+}
+
+// readThermalZone 读取温区温度，critical/hot 类型的 trip point 分别作为 crit/max 阈值
+func (c *LinuxCollector) readThermalZone(zone string) (model.SensorStat, bool) {
+	dir := c.sysPath("class", "thermal", zone)
+	value, ok := readSysfsFloat(filepath.Join(dir, "temp"), 1000)
+	if !ok {
+		return model.SensorStat{}, false
+	}
+	zoneType := readSysfsString(filepath.Join(dir, "type"))
+	s := model.SensorStat{
+		Chip:   zoneType,
+		Device: zone,
+		Sensor: "temp",
+		Label:  zoneType,
+		Type:   model.SensorTemperature,
+		Value:  value,
+	}
+	for _, file := range readDirNames(dir) {
+		prefix, ok := strings.CutSuffix(file, "_type")
+		if !ok || !strings.HasPrefix(prefix, "trip_point_") {
+			continue
+		}
+		temp, ok := readSysfsFloat(filepath.Join(dir, prefix+"_temp"), 1000)
+		if !ok || temp <= 0 {
+			continue
+		}
+		switch readSysfsString(filepath.Join(dir, file)) {
+		case "critical":
+			if s.Crit == 0 || temp < s.Crit {
+				s.Crit = temp
+			}
+		case "hot":
+			if s.Max == 0 || temp < s.Max {
+				s.Max = temp
+			}
+		}
+	}
+	return s, true
+}
+
+// readSysfsFloat 读取整数属性并除以 scale，文件不存在或无法解析时 ok 为 false
+func readSysfsFloat(filename string, scale float64) (float64, bool) {
+	v, err := strconv.ParseInt(readSysfsString(filename), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return float64(v) / scale, true
+}
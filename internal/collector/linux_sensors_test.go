@@ -0,0 +1,38 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+	"tisminSRETool/internal/model"
+)
+
+// testdata/sys 包含：带 _label/_max/_crit 的 coretemp、风扇与电压混合的 nct6775、
+// 属性位于 device 子目录的旧内核 hwmon，以及带 critical/hot trip point 的温区
+func TestCollectSensors(t *testing.T) {
+	c := &LinuxCollector{sysRoot: "testdata/sys"}
+	got, err := c.CollectSensors()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []model.SensorStat{
+		{Chip: "coretemp", Device: "hwmon0", Sensor: "temp1", Label: "Package id 0", Type: model.SensorTemperature, Value: 45, Max: 80, Crit: 100},
+		{Chip: "coretemp", Device: "hwmon0", Sensor: "temp2", Type: model.SensorTemperature, Value: 47.5, Alarm: true},
+		// fan2_input 为空（传感器离线）、in0_input 为电压，均不输出
+		{Chip: "nct6775", Device: "hwmon1", Sensor: "fan1", Label: "CPU_FAN", Type: model.SensorFan, Value: 1200, Min: 300},
+		{Chip: "oldchip", Device: "hwmon2", Sensor: "temp1", Type: model.SensorTemperature, Value: 30},
+		// passive 与温度为 0 的 trip point 忽略；没有 temp 的 thermal_zone1 和 cooling_device 不输出
+		{Chip: "x86_pkg_temp", Device: "thermal_zone0", Sensor: "temp", Label: "x86_pkg_temp", Type: model.SensorTemperature, Value: 52, Max: 95, Crit: 105},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectSensors mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestCollectSensorsMissing(t *testing.T) {
+	c := &LinuxCollector{sysRoot: t.TempDir()}
+	got, err := c.CollectSensors()
+	if err != nil || len(got) != 0 {
+		t.Errorf("CollectSensors() = %+v, %v; want empty without error", got, err)
+	}
+}
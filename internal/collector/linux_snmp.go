@@ -0,0 +1,76 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"tisminSRETool/internal/model"
+	"tisminSRETool/pkg/utils"
+)
+
+// 采集的协议计数器，按协议分组；只保留排查重传、队列溢出、丢包时常用的项以控制指标数量
+// CurrEstab 等瞬时值不在此列，连接数见 TCPStat
+var protoCounterNames = map[string][]string{
+	"Ip": {"InReceives", "InHdrErrors", "InAddrErrors", "InDiscards", "InDelivers", "OutRequests", "OutDiscards", "OutNoRoutes", "ReasmFails"},
+	"Tcp": {"ActiveOpens", "PassiveOpens", "AttemptFails", "EstabResets", "InSegs", "OutSegs", "RetransSegs",
+		"InErrs", "OutRsts", "InCsumErrors"},
+	"Udp": {"InDatagrams", "NoPorts", "InErrors", "OutDatagrams", "RcvbufErrors", "SndbufErrors", "InCsumErrors"},
+	"TcpExt": {"ListenOverflows", "ListenDrops", "TCPTimeouts", "TCPSynRetrans", "TCPLostRetransmit", "TCPFastRetrans",
+		"TCPAbortOnTimeout", "TCPAbortOnData", "TCPAbortOnMemory", "TCPBacklogDrop", "TCPReqQFullDrop",
+		"SyncookiesSent", "SyncookiesFailed", "PruneCalled", "TCPRcvQDrop"},
+}
+
+// CollectProtoStats 读取 /proc/net/snmp 与 /proc/net/netstat 中的内核协议栈计数器
+// 两个文件格式相同：每个协议两行，第一行为字段名，第二行为对应的值
+func (c *LinuxCollector) CollectProtoStats(ctx context.Context) (model.ProtoStat, error) {
+	ret := model.ProtoStat{Counters: make(map[string]uint64)}
+
+	if err := readSNMPFile(ctx, c.procPath("net", "snmp"), ret.Counters); err != nil {
+		return model.ProtoStat{}, err
+	}
+	// 部分精简内核未开启 /proc/net/netstat，只有 snmp 时也返回已读到的数据
+	if err := readSNMPFile(ctx, c.procPath("net", "netstat"), ret.Counters); err != nil && !os.IsNotExist(err) {
+		return model.ProtoStat{}, err
+	}
+	return ret, nil
+}
+
+func readSNMPFile(ctx context.Context, filename string, counters map[string]uint64) error {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, filename, 0, -1)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i+1 < len(lines); i += 2 {
+		names := strings.Fields(lines[i])
+		values := strings.Fields(lines[i+1])
+		if len(names) == 0 || len(names) != len(values) || names[0] != values[0] {
+			return fmt.Errorf("invalid format of %s: %s", filename, lines[i])
+		}
+		proto := strings.TrimSuffix(names[0], ":")
+		wanted, ok := protoCounterNames[proto]
+		if !ok {
+			continue
+		}
+
+		index := make(map[string]int, len(names))
+		for j, name := range names[1:] {
+			index[name] = j + 1
+		}
+		for _, name := range wanted {
+			j, ok := index[name]
+			if !ok {
+				continue
+			}
+			// 个别字段（如 Tcp MaxConn）可能为 -1，非计数器值直接跳过
+			v, err := strconv.ParseUint(values[j], 10, 64)
+			if err != nil {
+				continue
+			}
+			counters[proto+"."+name] = v
+		}
+	}
+	return nil
+}
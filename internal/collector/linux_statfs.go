@@ -0,0 +1,84 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const defaultStatfsTimeout = 2 * time.Second
+
+// errStaleMount statfs 超时未返回，通常是 NFS/CIFS 服务端失联导致挂载点卡死
+var errStaleMount = errors.New("statfs timed out, mount is unresponsive")
+
+// statfsResult 一次 statfs 调用的结果
+type statfsResult struct {
+	total, free, avail, inodes, inodesFree uint64
+	err                                    error
+}
+
+// statfsState 记录仍未返回的 statfs 调用
+// 卡死在内核中的 statfs 无法被取消，同一挂载点在其返回前不再发起新的调用，避免 goroutine 越积越多
+type statfsState struct {
+	mu      sync.Mutex
+	pending map[string]bool
+}
+
+// statfsCall 一次异步 statfs 调用，每个挂载点从发起调用起单独计算超时
+type statfsCall struct {
+	ch       chan statfsResult
+	deadline time.Time
+}
+
+// startStatFS 在独立 goroutine 中执行 statfs
+// 该挂载点上一次的调用仍未返回时返回 nil，调用方应直接视为 stale
+func (c *LinuxCollector) startStatFS(mountPoint string) *statfsCall {
+	c.statfs.mu.Lock()
+	if c.statfs.pending == nil {
+		c.statfs.pending = make(map[string]bool)
+	}
+	if c.statfs.pending[mountPoint] {
+		c.statfs.mu.Unlock()
+		return nil
+	}
+	c.statfs.pending[mountPoint] = true
+	c.statfs.mu.Unlock()
+
+	timeout := c.statfsTimeout
+	if timeout <= 0 {
+		timeout = defaultStatfsTimeout
+	}
+	call := &statfsCall{ch: make(chan statfsResult, 1), deadline: time.Now().Add(timeout)}
+	go func() {
+		res := c.statFS(mountPoint)
+		c.statfs.mu.Lock()
+		delete(c.statfs.pending, mountPoint)
+		c.statfs.mu.Unlock()
+		call.ch <- res
+	}()
+	return call
+}
+
+// wait 等待结果，超过该挂载点的截止时间仍未返回视为 stale
+// 其他挂载点等待所花的时间不计入，已返回的结果总是优先取用
+func (call *statfsCall) wait(ctx context.Context) statfsResult {
+	if call == nil {
+		return statfsResult{err: errStaleMount}
+	}
+	select {
+	case res := <-call.ch:
+		return res
+	default:
+	}
+	timer := time.NewTimer(time.Until(call.deadline))
+	defer timer.Stop()
+	select {
+	case res := <-call.ch:
+		return res
+	case <-timer.C:
+		return statfsResult{err: errStaleMount}
+	case <-ctx.Done():
+		return statfsResult{err: ctx.Err()}
+	}
+}
@@ -0,0 +1,112 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"tisminSRETool/internal/model"
+	"tisminSRETool/pkg/utils"
+)
+
+// /proc/net/tcp 中 st 列（十六进制）到连接状态名的映射，见 include/net/tcp_states.h
+var tcpStateNames = map[string]string{
+	"01": "ESTABLISHED",
+	"02": "SYN_SENT",
+	"03": "SYN_RECV",
+	"04": "FIN_WAIT1",
+	"05": "FIN_WAIT2",
+	"06": "TIME_WAIT",
+	"07": "CLOSE",
+	"08": "CLOSE_WAIT",
+	"09": "LAST_ACK",
+	"0A": "LISTEN",
+	"0B": "CLOSING",
+	"0C": "NEW_SYN_RECV",
+}
+
+// CollectTCP 统计 IPv4/IPv6 TCP 各状态连接数，并读取 /proc/net/sockstat 的套接字汇总
+func (c *LinuxCollector) CollectTCP(ctx context.Context) (*model.TCPStat, error) {
+	ret := &model.TCPStat{States: make(map[string]uint64, len(tcpStateNames))}
+	for _, name := range tcpStateNames {
+		ret.States[name] = 0
+	}
+
+	if err := c.countTCPStates(ctx, c.procPath("net", "tcp"), ret); err != nil {
+		return nil, err
+	}
+	// 关闭 IPv6 的内核上没有 tcp6
+	if err := c.countTCPStates(ctx, c.procPath("net", "tcp6"), ret); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err := c.readSockstat(ctx, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (c *LinuxCollector) countTCPStates(ctx context.Context, path string, stat *model.TCPStat) error {
+	// 第一行为表头
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, path, 1, -1)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		name, ok := tcpStateNames[strings.ToUpper(fields[3])]
+		if !ok {
+			continue
+		}
+		stat.States[name]++
+		stat.Total++
+	}
+	return nil
+}
+
+// readSockstat 解析 /proc/net/sockstat，格式如：
+//
+//	sockets: used 18
+//	TCP: inuse 4 orphan 0 tw 0 alloc 4 mem 0
+func (c *LinuxCollector) readSockstat(ctx context.Context, stat *model.TCPStat) error {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("net", "sockstat"), 0, -1)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		proto := strings.TrimSuffix(fields[0], ":")
+		if proto != "sockets" && proto != "TCP" {
+			continue
+		}
+		for i := 1; i+1 < len(fields); i += 2 {
+			val, err := strconv.ParseUint(fields[i+1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid format of /proc/net/sockstat: %s", line)
+			}
+			switch proto + "." + fields[i] {
+			case "sockets.used":
+				stat.SocketsUsed = val
+			case "TCP.inuse":
+				stat.InUse = val
+			case "TCP.orphan":
+				stat.Orphan = val
+			case "TCP.tw":
+				stat.TimeWaitSockets = val
+			case "TCP.alloc":
+				stat.Alloc = val
+			case "TCP.mem":
+				// 单位为页
+				stat.MemBytes = val * uint64(os.Getpagesize())
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+	"tisminSRETool/internal/model"
+)
+
+func TestCollectTCP(t *testing.T) {
+	c := &LinuxCollector{procRoot: "testdata/proc"}
+	got, err := c.CollectTCP(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 没有连接的状态也输出 0
+	states := make(map[string]uint64, len(tcpStateNames))
+	for _, name := range tcpStateNames {
+		states[name] = 0
+	}
+	states["LISTEN"] = 3
+	states["ESTABLISHED"] = 2
+	states["TIME_WAIT"] = 1
+	states["CLOSE_WAIT"] = 1
+	want := &model.TCPStat{
+		States:          states,
+		Total:           7,
+		SocketsUsed:     218,
+		InUse:           6,
+		Orphan:          1,
+		TimeWaitSockets: 3,
+		Alloc:           9,
+		MemBytes:        4 * uint64(os.Getpagesize()),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectTCP mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
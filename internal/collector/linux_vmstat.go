@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"tisminSRETool/internal/model"
+	"tisminSRETool/pkg/utils"
+)
+
+// CollectVMStat 读取 /proc/vmstat 中与缺页、换页、内存回收和 OOM 相关的累计计数器
+// 速率由 engine 基于上一轮计算
+func (c *LinuxCollector) CollectVMStat(ctx context.Context) (model.VMStat, error) {
+	lines, err := utils.ReadLinesOffsetNWithContext(ctx, c.procPath("vmstat"), 0, -1)
+	if err != nil {
+		return model.VMStat{}, err
+	}
+
+	var ret model.VMStat
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		key := fields[0]
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case key == "pgfault":
+			ret.PgFault = v
+		case key == "pgmajfault":
+			ret.PgMajFault = v
+		case key == "pswpin":
+			ret.PswpIn = v
+		case key == "pswpout":
+			ret.PswpOut = v
+		case key == "oom_kill":
+			ret.OOMKill = v
+		// 4.8 之前的内核按 zone 拆分，如 pgscan_kswapd_normal，按前缀累加
+		// pgscan_anon/pgscan_file 是同一批扫描按页类型的另一种拆分，不能重复累加
+		case strings.HasPrefix(key, "pgscan_kswapd"):
+			ret.PgScanKswapd += v
+		case strings.HasPrefix(key, "pgscan_direct") && key != "pgscan_direct_throttle":
+			ret.PgScanDirect += v
+		case strings.HasPrefix(key, "pgsteal_kswapd"):
+			ret.PgStealKswapd += v
+		case strings.HasPrefix(key, "pgsteal_direct"):
+			ret.PgStealDirect += v
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,125 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	"tisminSRETool/internal/alert"
+	"tisminSRETool/internal/model"
+)
+
+// 每轮采集都写盘更新 LastSeen，异常断电后估算的停机时长误差不超过一个采集周期
+const bootStateFile = "boot_state.json"
+
+// 没有 boot_id 时按启动时间判断重启，btime 会随 NTP 校时小幅漂移，需要留出余量
+const bootTimeTolerance = 60
+
+// bootState 持久化的上一次启动信息，用于在采集器重启后识别主机是否重启过
+type bootState struct {
+	BootID   string    `json:"boot_id"`
+	BootTime int64     `json:"boot_time"`
+	LastSeen time.Time `json:"last_seen"` // 最后一次成功采集的时间，用于估算停机时长
+}
+
+func loadBootState(path string) (*bootState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var st bootState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &st, nil
+}
+
+// saveBootState 先写临时文件再 rename，避免断电时留下半截文件
+func saveBootState(path string, st *bootState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// sameBoot 判断两次采集是否属于同一次启动，信息缺失时视为同一次
+func sameBoot(prevID string, prevTime int64, cur model.HostInfo) bool {
+	if prevID != "" && cur.BootID != "" {
+		return prevID == cur.BootID
+	}
+	if prevTime > 0 && cur.BootTime > 0 {
+		d := cur.BootTime - prevTime
+		return d < bootTimeTolerance && d > -bootTimeTolerance
+	}
+	return true
+}
+
+// checkReboot 与上一次记录的启动信息比较，主机重启过时返回告警，并更新持久化状态
+func (r *Runner) checkReboot(info model.HostInfo, now time.Time) []alert.Alert {
+	if info.BootID == "" && info.BootTime == 0 {
+		return nil
+	}
+
+	if !r.bootLoaded {
+		r.bootLoaded = true
+		if r.stateDir != "" {
+			st, err := loadBootState(filepath.Join(r.stateDir, bootStateFile))
+			if err != nil && r.logger != nil {
+				r.logger.Printf("load boot state failed: %v", err)
+			}
+			r.boot = st
+		}
+	}
+
+	var alerts []alert.Alert
+	if prev := r.boot; prev != nil && !sameBoot(prev.BootID, prev.BootTime, info) {
+		bootAt := time.Unix(info.BootTime, 0)
+		downtime := "unknown"
+		value := 0.0
+		if !prev.LastSeen.IsZero() && bootAt.After(prev.LastSeen) {
+			d := bootAt.Sub(prev.LastSeen).Round(time.Second)
+			downtime = d.String()
+			value = d.Seconds()
+		}
+		msg := fmt.Sprintf("Host rebooted at %s, last seen before reboot %s, downtime %s",
+			bootAt.Format(time.RFC3339), prev.LastSeen.Format(time.RFC3339), downtime)
+		if r.logger != nil {
+			r.logger.Print(msg)
+		}
+		alerts = append(alerts, alert.Alert{
+			Level:     alert.LevelWarn,
+			Category:  alert.CategoryHost,
+			Metric:    "reboot",
+			Message:   msg,
+			Value:     value,
+			Unit:      "s",
+			Timestamp: now,
+		})
+	}
+
+	r.boot = &bootState{BootID: info.BootID, BootTime: info.BootTime, LastSeen: now}
+	r.saveBoot()
+	return alerts
+}
+
+// saveBoot 将当前启动信息写入状态目录
+func (r *Runner) saveBoot() {
+	if r.stateDir == "" || r.boot == nil {
+		return
+	}
+	if err := saveBootState(filepath.Join(r.stateDir, bootStateFile), r.boot); err != nil && r.logger != nil {
+		r.logger.Printf("save boot state failed: %v", err)
+	}
+}
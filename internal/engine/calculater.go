@@ -11,45 +11,108 @@ func CalculateRate(prev, cur model.Metrics, interval time.Duration) model.Metric
 	}
 	seconds := interval.Seconds()
 	res := cur
-	var used uint64
 
-	// CPU相关信息计算
-	diffTotal := cur.CPU.TotalTicks - prev.CPU.TotalTicks
-	diffIdle := cur.CPU.IdleTicks - prev.CPU.IdleTicks
-	if diffTotal > 0 && diffIdle <= diffTotal {
-		used = diffTotal - diffIdle
-	}
-	if diffTotal > 0 {
-		res.CPU.UsagePercent = float64(used) / float64(diffTotal) * 100
-	}
+	// CPU 使用率由采集器基于上一轮 /proc/stat 快照计算，这里不再重复计算
 
-	// 内存相关信息计算
+	// 内存相关信息计算：pgfault 在运行中的系统上不会为 0，以此判断上一轮是否采集成功
+	if prev.VM.PgFault > 0 && cur.VM.PgFault > 0 {
+		res.VM.Rates = model.VMStatRates{
+			PgFault:    counterRate(prev.VM.PgFault, cur.VM.PgFault, seconds),
+			PgMajFault: counterRate(prev.VM.PgMajFault, cur.VM.PgMajFault, seconds),
+			PswpIn:     counterRate(prev.VM.PswpIn, cur.VM.PswpIn, seconds),
+			PswpOut:    counterRate(prev.VM.PswpOut, cur.VM.PswpOut, seconds),
+			PgScan:     counterRate(prev.VM.PgScanKswapd+prev.VM.PgScanDirect, cur.VM.PgScanKswapd+cur.VM.PgScanDirect, seconds),
+			PgSteal:    counterRate(prev.VM.PgStealKswapd+prev.VM.PgStealDirect, cur.VM.PgStealKswapd+cur.VM.PgStealDirect, seconds),
+			OOMKill:    counterRate(prev.VM.OOMKill, cur.VM.OOMKill, seconds),
+		}
+	}
 
-	// 磁盘相关信息计算
-	for i := range res.Disk {
-		if i < len(prev.Disk) && res.Disk[i].Device == prev.Disk[i].Device {
-			res.Disk[i].ReadSpeed = float64(res.Disk[i].Read-prev.Disk[i].Read) / seconds
-			res.Disk[i].WriteSpeed = float64(res.Disk[i].Write-prev.Disk[i].Write) / seconds
+	// 磁盘 IO 速率、await、util 由采集器基于上一轮 /proc/diskstats 计算
 
-			diffReadIOs := res.Disk[i].Read - prev.Disk[i].Read
-			diffWriteIOs := res.Disk[i].Write - prev.Disk[i].Write
-			diffIOTime := float64(res.Disk[i].IOQueueTime - prev.Disk[i].IOQueueTime)
-			totalIOs := diffReadIOs + diffWriteIOs
+	// 网络相关信息计算：按网卡名匹配上一轮，网卡增删或计数器回绕时跳过本轮
+	prevNet := make(map[string]model.NetStat, len(prev.Net))
+	for _, n := range prev.Net {
+		prevNet[n.Name] = n
+	}
+	res.Net = append([]model.NetStat(nil), cur.Net...)
+	for i := range res.Net {
+		n := &res.Net[i]
+		p, ok := prevNet[n.Name]
+		if !ok || n.RxBytes < p.RxBytes || n.TxBytes < p.TxBytes {
+			continue
+		}
+		n.RxSpeed = float64(n.RxBytes-p.RxBytes) / seconds
+		n.TxSpeed = float64(n.TxBytes-p.TxBytes) / seconds
+		if n.SpeedMbps > 0 {
+			linkBytes := float64(n.SpeedMbps) * 1e6 / 8
+			n.RxUtilization = n.RxSpeed / linkBytes * 100
+			n.TxUtilization = n.TxSpeed / linkBytes * 100
+		}
+	}
 
-			if totalIOs > 0 && diffIOTime > 0 {
-				res.Disk[i].Await = diffIOTime / float64(totalIOs)
-				totalMs := seconds * 1000
-				res.Disk[i].Util = diffIOTime / totalMs * 100
+	// 协议栈计数器速率，计数器回绕或重置时跳过该项
+	if len(cur.Proto.Counters) > 0 && len(prev.Proto.Counters) > 0 {
+		rates := make(map[string]float64, len(cur.Proto.Counters))
+		for name, v := range cur.Proto.Counters {
+			if p, ok := prev.Proto.Counters[name]; ok && v >= p {
+				rates[name] = float64(v-p) / seconds
 			}
 		}
+		res.Proto.Rates = rates
+		if outSegs := rates["Tcp.OutSegs"]; outSegs > 0 {
+			res.Proto.TCPRetransRatio = rates["Tcp.RetransSegs"] / outSegs * 100
+		}
 	}
 
-	// 网络相关信息计算
-	for i := range res.Net {
-		if i < len(prev.Net) && res.Net[i].Name == prev.Net[i].Name {
-			res.Net[i].RxSpeed = float64(res.Net[i].RxBytes-prev.Net[i].RxBytes) / seconds
-			res.Net[i].TxSpeed = float64(res.Net[i].TxBytes-prev.Net[i].TxBytes) / seconds
+	// 日志匹配速率（每分钟），按规则名 + 标签匹配上一轮
+	if len(cur.Logs) > 0 {
+		prevLogs := make(map[string]uint64, len(prev.Logs))
+		for _, l := range prev.Logs {
+			prevLogs[l.SeriesKey()] = l.Count
+		}
+		res.Logs = append([]model.LogMatchStat(nil), cur.Logs...)
+		for i := range res.Logs {
+			l := &res.Logs[i]
+			// 新出现的序列上一轮计数视为 0
+			l.Rate = counterRate(prevLogs[l.SeriesKey()], l.Count, seconds) * 60
+		}
+	}
+
+	// NFS 逐操作速率与区间平均延迟，按挂载点 + 操作名匹配上一轮
+	if len(cur.NFS) > 0 {
+		prevOps := make(map[string]model.NFSOpStat)
+		for _, m := range prev.NFS {
+			for _, op := range m.Ops {
+				prevOps[m.MountPoint+"\x00"+op.Op] = op
+			}
+		}
+		res.NFS = make([]model.NFSMountStat, len(cur.NFS))
+		for i, m := range cur.NFS {
+			m.Ops = append([]model.NFSOpStat(nil), m.Ops...)
+			for j := range m.Ops {
+				op := &m.Ops[j]
+				p, ok := prevOps[m.MountPoint+"\x00"+op.Op]
+				// 重新挂载后计数器清零
+				if !ok || op.Ops < p.Ops {
+					continue
+				}
+				op.OpsRate = counterRate(p.Ops, op.Ops, seconds)
+				op.RetransRate = counterRate(p.Retransmissions, op.Retransmissions, seconds)
+				if n := op.Ops - p.Ops; n > 0 {
+					op.AvgRTTMs = float64(op.RTTMs-min(p.RTTMs, op.RTTMs)) / float64(n)
+					op.AvgExecMs = float64(op.ExecuteMs-min(p.ExecuteMs, op.ExecuteMs)) / float64(n)
+				}
+			}
+			res.NFS[i] = m
 		}
 	}
 	return res
 }
+
+// counterRate 计算累计计数器的每秒速率，计数器重置时返回 0
+func counterRate(prev, cur uint64, seconds float64) float64 {
+	if cur < prev {
+		return 0
+	}
+	return float64(cur-prev) / seconds
+}
@@ -18,6 +18,11 @@ type Runner struct {
 	sender    alert.AlertSender
 	emailCfg  model.EmailConfig
 
+	// 重启检测状态，只在 collectOnce 中访问
+	stateDir   string
+	boot       *bootState
+	bootLoaded bool
+
 	mu       sync.RWMutex
 	last     *model.Metrics
 	lastErrs *model.CollectErrors
@@ -49,6 +54,12 @@ func (r *Runner) SetAlerting(checker alert.AlertChecker, sender alert.AlertSende
 	r.emailCfg = emailCfg
 }
 
+// SetStateDir 设置持久化状态目录，用于在采集器重启后仍能识别主机重启
+// 需在 Run 之前调用，未设置时只能识别采集器运行期间的重启
+func (r *Runner) SetStateDir(dir string) {
+	r.stateDir = dir
+}
+
 func (r *Runner) Run(ctx context.Context) {
 	if ctx == nil {
 		ctx = context.Background()
@@ -57,6 +68,12 @@ func (r *Runner) Run(ctx context.Context) {
 	ticker := time.NewTicker(r.interval)
 	defer ticker.Stop()
 
+	// 流式事件（如内核日志）不等待采集周期，收到即告警
+	var events <-chan model.KernelEvent
+	if src, ok := r.collector.(collector.EventSource); ok {
+		events = src.Events(ctx)
+	}
+
 	r.collectOnce(ctx)
 
 	for {
@@ -68,6 +85,12 @@ func (r *Runner) Run(ctx context.Context) {
 			return
 		case <-ticker.C:
 			r.collectOnce(ctx)
+		case ev, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			r.processEvents(ctx, drainEvents(ev, events))
 		}
 	}
 }
@@ -84,57 +107,124 @@ func (r *Runner) collectOnce(parent context.Context) {
 	defer cancel()
 
 	metrics, errs := r.collector.Collect(collectCtx)
+	now := time.Now()
+
+	var rebootAlerts []alert.Alert
+	if metrics != nil {
+		rebootAlerts = r.checkReboot(metrics.Info, now)
+	}
 
 	r.mu.Lock()
+	// 基于上一轮累计计数器计算速率，首轮没有基线时速率为 0
+	// 主机重启后计数器全部归零，不能与重启前的基线相减
+	if metrics != nil && r.last != nil && sameBoot(r.last.Info.BootID, r.last.Info.BootTime, metrics.Info) {
+		rated := CalculateRate(*r.last, *metrics, now.Sub(r.lastAt))
+		metrics = &rated
+	}
 	r.last = metrics
 	r.lastErrs = errs
-	r.lastAt = time.Now()
+	r.lastAt = now
 	r.mu.Unlock()
 
-	if r.logger == nil {
+	if metrics == nil {
+		if r.logger != nil {
+			r.logger.Printf("collect finished with empty metrics")
+		}
 		return
 	}
 
-	if errs != nil && errs.HasError() {
-		r.logger.Printf("collect finished with errors: %+v", errs)
-		return
+	// 部分子系统采集失败（如内核不支持 PSI）时仍对已采集到的数据做告警检查
+	if r.logger != nil {
+		if errs != nil && errs.HasError() {
+			r.logger.Printf("collect finished with errors: %+v", errs)
+		} else {
+			r.logger.Printf("collect finished: host=%s ts=%s", metrics.Host, metrics.UpdateTimestamp)
+		}
 	}
+	r.processAlerts(parent, metrics, rebootAlerts)
+}
 
-	if metrics == nil {
-		r.logger.Printf("collect finished with empty metrics")
+// processAlerts 执行规则检查，并与 Runner 自身产生的事件告警（如重启）合并发送
+// 事件告警只产生一次，未配置规则检查或检查失败时也照常发送
+func (r *Runner) processAlerts(ctx context.Context, metrics *model.Metrics, extra []alert.Alert) {
+	r.mu.RLock()
+	checker := r.checker
+	r.mu.RUnlock()
+
+	var alerts []alert.Alert
+	if checker != nil {
+		checked, err := checker.Check(ctx, metrics)
+		if err != nil {
+			if r.logger != nil {
+				r.logger.Printf("alert check failed: %v", err)
+			}
+		} else {
+			alerts = checked
+		}
+	}
+	alerts = append(alerts, extra...)
+	if len(alerts) == 0 {
 		return
 	}
 
-	r.logger.Printf("collect finished: host=%s ts=%s", metrics.Host, metrics.UpdateTimestamp)
-	r.processAlerts(parent, metrics)
+	r.sendAlerts(ctx, metrics.Host, alerts)
 }
 
-func (r *Runner) processAlerts(ctx context.Context, metrics *model.Metrics) {
+// processEvents 将流式事件转换为告警立即发送
+func (r *Runner) processEvents(ctx context.Context, events []model.KernelEvent) {
 	r.mu.RLock()
 	checker := r.checker
-	sender := r.sender
-	emailCfg := r.emailCfg
+	last := r.last
 	r.mu.RUnlock()
 
-	if checker == nil || metrics == nil {
-		return
-	}
-
-	alerts, err := checker.Check(ctx, metrics)
-	if err != nil {
+	for _, ev := range events {
 		if r.logger != nil {
-			r.logger.Printf("alert check failed: %v", err)
+			r.logger.Printf("kernel event: type=%s process=%s pid=%d device=%s msg=%q", ev.Type, ev.Process, ev.PID, ev.Device, ev.Message)
 		}
-		return
 	}
-	if len(alerts) == 0 {
+	// 与规则告警一致，未启用告警时只记录日志
+	if checker == nil {
 		return
 	}
 
+	alerts := make([]alert.Alert, 0, len(events))
+	for _, ev := range events {
+		alerts = append(alerts, alert.KernelEventAlert(ev))
+	}
+
+	host := ""
+	if last != nil {
+		host = last.Host
+	}
+	r.sendAlerts(ctx, host, alerts)
+}
+
+// drainEvents 合并通道中已就绪的事件，避免 OOM 风暴等场景逐条发送邮件
+func drainEvents(first model.KernelEvent, events <-chan model.KernelEvent) []model.KernelEvent {
+	batch := []model.KernelEvent{first}
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return batch
+			}
+			batch = append(batch, ev)
+		default:
+			return batch
+		}
+	}
+}
+
+func (r *Runner) sendAlerts(ctx context.Context, host string, alerts []alert.Alert) {
+	r.mu.RLock()
+	sender := r.sender
+	emailCfg := r.emailCfg
+	r.mu.RUnlock()
+
 	now := time.Now()
 	for i := range alerts {
 		if alerts[i].Host == "" {
-			alerts[i].Host = metrics.Host
+			alerts[i].Host = host
 		}
 		if alerts[i].Timestamp.IsZero() {
 			alerts[i].Timestamp = now
@@ -0,0 +1,273 @@
+package exporter
+
+import (
+	"sort"
+	"strings"
+	"tisminSRETool/internal/model"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	protoCounterDesc = prometheus.NewDesc(
+		"system_netstat_total",
+		"内核协议栈累计计数器(/proc/net/snmp、/proc/net/netstat)",
+		[]string{"host", "protocol", "counter"}, nil,
+	)
+	vmstatCounterDesc = prometheus.NewDesc(
+		"system_vmstat_total",
+		"缺页、换页、内存回收与 OOM 累计计数器(/proc/vmstat)",
+		[]string{"host", "counter"}, nil,
+	)
+	softirqDesc = prometheus.NewDesc(
+		"system_softirqs_total",
+		"逐 CPU 软中断次数(/proc/softirqs)",
+		[]string{"host", "cpu", "type"}, nil,
+	)
+	interruptDesc = prometheus.NewDesc(
+		"system_interrupts_total",
+		"逐 CPU 硬中断次数(/proc/interrupts)，计数为 0 的 CPU 不输出",
+		[]string{"host", "cpu", "irq"}, nil,
+	)
+	interruptInfoDesc = prometheus.NewDesc(
+		"system_interrupt_info",
+		"硬中断对应的中断控制器与设备描述，值恒为 1",
+		[]string{"host", "irq", "device"}, nil,
+	)
+	cpuThrottleDesc = prometheus.NewDesc(
+		"system_cpu_throttle_total",
+		"CPU 过热降频次数(scope=core/package)",
+		[]string{"host", "cpu", "scope"}, nil,
+	)
+	kernelEventDesc = prometheus.NewDesc(
+		"system_kernel_events_total",
+		"内核日志中识别出的事件数(/dev/kmsg)，从采集器启动开始累计",
+		[]string{"host", "type"}, nil,
+	)
+	psiStallDesc = prometheus.NewDesc(
+		"system_pressure_stall_seconds_total",
+		"PSI 累计停顿时间(秒)",
+		[]string{"host", "resource", "type"}, nil,
+	)
+	nfsLabels      = []string{"host", "mount", "export", "op"}
+	nfsOpsDesc     = prometheus.NewDesc("system_nfs_ops_total", "NFS RPC 请求数", nfsLabels, nil)
+	nfsRetransDesc = prometheus.NewDesc("system_nfs_retransmissions_total", "NFS RPC 重传次数", nfsLabels, nil)
+	nfsTimeoutDesc = prometheus.NewDesc("system_nfs_major_timeouts_total", "NFS RPC 主超时次数", nfsLabels, nil)
+	nfsErrorsDesc  = prometheus.NewDesc("system_nfs_errors_total", "NFS RPC 返回错误的请求数", nfsLabels, nil)
+	nfsQueueDesc   = prometheus.NewDesc("system_nfs_queue_seconds_total", "NFS RPC 累计排队时间", nfsLabels, nil)
+	nfsRTTDesc     = prometheus.NewDesc("system_nfs_rtt_seconds_total", "NFS RPC 累计往返时间", nfsLabels, nil)
+	nfsExecDesc    = prometheus.NewDesc("system_nfs_execute_seconds_total", "NFS RPC 累计执行时间", nfsLabels, nil)
+	nfsBytesDesc   = prometheus.NewDesc("system_nfs_bytes_total", "NFS RPC 收发字节数", append(nfsLabels[:len(nfsLabels):len(nfsLabels)], "direction"), nil)
+)
+
+// counterCollector 在抓取时根据最近一次快照输出内核与 NFS 客户端累计计数器
+// 内核计数器是单调累计值，用 ConstMetric 直接以 Counter 类型暴露，避免 GaugeVec 无法表达计数器语义
+type counterCollector struct {
+	e *PrometheusExporter
+}
+
+func (c counterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- protoCounterDesc
+	ch <- vmstatCounterDesc
+	ch <- softirqDesc
+	ch <- interruptDesc
+	ch <- interruptInfoDesc
+	ch <- cpuThrottleDesc
+	ch <- kernelEventDesc
+	ch <- psiStallDesc
+	for _, d := range []*prometheus.Desc{nfsOpsDesc, nfsRetransDesc, nfsTimeoutDesc, nfsErrorsDesc, nfsQueueDesc, nfsRTTDesc, nfsExecDesc, nfsBytesDesc} {
+		ch <- d
+	}
+}
+
+func (c counterCollector) Collect(ch chan<- prometheus.Metric) {
+	c.e.mu.RLock()
+	metrics := c.e.metrics
+	c.e.mu.RUnlock()
+	if metrics == nil {
+		return
+	}
+
+	host := metrics.Host
+	if host == "" {
+		host = "unknown"
+	}
+	for name, v := range metrics.Proto.Counters {
+		proto, counter, ok := strings.Cut(name, ".")
+		if !ok {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(protoCounterDesc, prometheus.CounterValue, float64(v), host, proto, counter)
+	}
+	collectVMStat(ch, host, metrics.VM)
+	collectNFS(ch, host, metrics.NFS)
+	collectIRQ(ch, host, metrics.IRQ)
+	collectKernelEvents(ch, host, metrics.Kernel)
+	collectPSI(ch, host, metrics.PSI)
+	for _, f := range metrics.CPUFreq {
+		ch <- prometheus.MustNewConstMetric(cpuThrottleDesc, prometheus.CounterValue, float64(f.CoreThrottleCount), host, f.CPU, "core")
+		ch <- prometheus.MustNewConstMetric(cpuThrottleDesc, prometheus.CounterValue, float64(f.PackageThrottleCount), host, f.CPU, "package")
+	}
+}
+
+func collectVMStat(ch chan<- prometheus.Metric, host string, vm model.VMStat) {
+	// vmstat 采集失败时整体为零值，不输出
+	if vm.PgFault == 0 {
+		return
+	}
+	counters := []struct {
+		name  string
+		value uint64
+	}{
+		{"pgfault", vm.PgFault},
+		{"pgmajfault", vm.PgMajFault},
+		{"pswpin", vm.PswpIn},
+		{"pswpout", vm.PswpOut},
+		{"pgscan_kswapd", vm.PgScanKswapd},
+		{"pgscan_direct", vm.PgScanDirect},
+		{"pgsteal_kswapd", vm.PgStealKswapd},
+		{"pgsteal_direct", vm.PgStealDirect},
+		{"oom_kill", vm.OOMKill},
+	}
+	for _, c := range counters {
+		ch <- prometheus.MustNewConstMetric(vmstatCounterDesc, prometheus.CounterValue, float64(c.value), host, c.name)
+	}
+}
+
+func collectPSI(ch chan<- prometheus.Metric, host string, psi model.PSIStat) {
+	if !psi.Available {
+		return
+	}
+	for _, r := range []struct {
+		name string
+		res  model.PSIResource
+	}{
+		{"cpu", psi.CPU},
+		{"memory", psi.Memory},
+		{"io", psi.IO},
+	} {
+		ch <- prometheus.MustNewConstMetric(psiStallDesc, prometheus.CounterValue, float64(r.res.Some.Total)/1e6, host, r.name, "some")
+		ch <- prometheus.MustNewConstMetric(psiStallDesc, prometheus.CounterValue, float64(r.res.Full.Total)/1e6, host, r.name, "full")
+	}
+}
+
+func collectNFS(ch chan<- prometheus.Metric, host string, mounts []model.NFSMountStat) {
+	counter := func(desc *prometheus.Desc, v float64, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, v, labels...)
+	}
+	for _, m := range mounts {
+		for _, op := range m.Ops {
+			counter(nfsOpsDesc, float64(op.Ops), host, m.MountPoint, m.Export, op.Op)
+			counter(nfsRetransDesc, float64(op.Retransmissions), host, m.MountPoint, m.Export, op.Op)
+			counter(nfsTimeoutDesc, float64(op.MajorTimeouts), host, m.MountPoint, m.Export, op.Op)
+			counter(nfsErrorsDesc, float64(op.Errors), host, m.MountPoint, m.Export, op.Op)
+			counter(nfsQueueDesc, float64(op.QueueMs)/1000, host, m.MountPoint, m.Export, op.Op)
+			counter(nfsRTTDesc, float64(op.RTTMs)/1000, host, m.MountPoint, m.Export, op.Op)
+			counter(nfsExecDesc, float64(op.ExecuteMs)/1000, host, m.MountPoint, m.Export, op.Op)
+			counter(nfsBytesDesc, float64(op.BytesSent), host, m.MountPoint, m.Export, op.Op, "sent")
+			counter(nfsBytesDesc, float64(op.BytesRecv), host, m.MountPoint, m.Export, op.Op, "received")
+		}
+	}
+}
+
+func collectIRQ(ch chan<- prometheus.Metric, host string, irq model.IRQStat) {
+	for _, s := range irq.SoftIRQs {
+		for i, v := range s.PerCPU {
+			if i < len(irq.CPUs) {
+				ch <- prometheus.MustNewConstMetric(softirqDesc, prometheus.CounterValue, float64(v), host, irq.CPUs[i], s.Type)
+			}
+		}
+	}
+	// 中断数 × CPU 数在多核机器上很大，且中断通常绑定在少数 CPU 上，只输出非 0 的计数；
+	// 设备描述放到单独的 info 指标，避免每个计数都带上长字符串标签
+	for _, in := range irq.Interrupts {
+		if in.Device != "" {
+			ch <- prometheus.MustNewConstMetric(interruptInfoDesc, prometheus.GaugeValue, 1, host, in.IRQ, in.Device)
+		}
+		// ERR/MIS 等只有一个全局计数的行不区分 CPU
+		if len(in.PerCPU) < len(irq.CPUs) {
+			ch <- prometheus.MustNewConstMetric(interruptDesc, prometheus.CounterValue, float64(in.Total), host, "", in.IRQ)
+			continue
+		}
+		for i, v := range in.PerCPU {
+			if v == 0 {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(interruptDesc, prometheus.CounterValue, float64(v), host, irq.CPUs[i], in.IRQ)
+		}
+	}
+}
+
+// kernelEventTypes 启用监听后即输出的事件类型，未发生的为 0，便于 increase() 从第一次事件开始计算
+var kernelEventTypes = []string{
+	model.KernelEventOOMKill,
+	model.KernelEventHungTask,
+	model.KernelEventFSError,
+	model.KernelEventIOError,
+	model.KernelEventLinkDown,
+	model.KernelEventLinkUp,
+}
+
+func collectKernelEvents(ch chan<- prometheus.Metric, host string, k model.KernelLogStat) {
+	// 未启用内核日志监听
+	if k.Events == nil {
+		return
+	}
+	for _, typ := range kernelEventTypes {
+		ch <- prometheus.MustNewConstMetric(kernelEventDesc, prometheus.CounterValue, float64(k.Events[typ]), host, typ)
+	}
+}
+
+// logMatchCollector 输出日志规则匹配计数 system_log_matches_total
+// 各规则的命名分组不同，标签集合取所有规则的并集，规则没有的标签为空值；
+// 标签在运行时才确定，因此 Describe 不声明描述符，作为 unchecked collector 注册
+type logMatchCollector struct {
+	e *PrometheusExporter
+}
+
+func (c logMatchCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (c logMatchCollector) Collect(ch chan<- prometheus.Metric) {
+	c.e.mu.RLock()
+	metrics := c.e.metrics
+	c.e.mu.RUnlock()
+	if metrics == nil || len(metrics.Logs) == 0 {
+		return
+	}
+
+	host := metrics.Host
+	if host == "" {
+		host = "unknown"
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, l := range metrics.Logs {
+		// 分组名在加载规则时已校验，不会与 host/rule 重名
+		for name := range l.Labels {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	desc := prometheus.NewDesc(
+		"system_log_matches_total",
+		"日志规则匹配行数，从开始跟踪文件起累计",
+		append([]string{"host", "rule"}, names...), nil,
+	)
+	for _, l := range metrics.Logs {
+		values := []string{host, l.Rule}
+		for _, name := range names {
+			values = append(values, l.Labels[name])
+		}
+		// 标签值来自日志内容，构造失败时跳过该序列，不影响其他指标输出
+		metric, err := prometheus.NewConstMetric(desc, prometheus.CounterValue, float64(l.Count), values...)
+		if err != nil {
+			continue
+		}
+		ch <- metric
+	}
+}
@@ -13,11 +13,17 @@ import (
 )
 
 type PrometheusExporter struct {
-	runner *engine.Runner
+	runner   *engine.Runner
+	textfile *textfileCollector
 
 	// CPU
 	cpuUsage      *prometheus.GaugeVec
 	cpuCoresUsage *prometheus.GaugeVec
+	cpuMode       *prometheus.GaugeVec
+	cpuCoreMode   *prometheus.GaugeVec
+	cpuFreq       *prometheus.GaugeVec
+	cpuFreqMin    *prometheus.GaugeVec
+	cpuFreqMax    *prometheus.GaugeVec
 	loadAvg1      *prometheus.GaugeVec
 	loadAvg5      *prometheus.GaugeVec
 	loadAvg15     *prometheus.GaugeVec
@@ -32,8 +38,12 @@ type PrometheusExporter struct {
 	swapUsed        *prometheus.GaugeVec
 	swapFree        *prometheus.GaugeVec
 	swapUsedPercent *prometheus.GaugeVec
+	memDetail       *prometheus.GaugeVec
+	memHugePages    *prometheus.GaugeVec
 
 	// Disk
+	diskInfo              *prometheus.GaugeVec
+	diskStale             *prometheus.GaugeVec
 	diskTotal             *prometheus.GaugeVec
 	diskUsed              *prometheus.GaugeVec
 	diskFree              *prometheus.GaugeVec
@@ -46,6 +56,13 @@ type PrometheusExporter struct {
 	diskWriteBytes        *prometheus.GaugeVec
 	diskAwait             *prometheus.GaugeVec
 	diskUtil              *prometheus.GaugeVec
+	diskReadIOPS          *prometheus.GaugeVec
+	diskWriteIOPS         *prometheus.GaugeVec
+	diskReadSpeed         *prometheus.GaugeVec
+	diskWriteSpeed        *prometheus.GaugeVec
+	diskReadAwait         *prometheus.GaugeVec
+	diskWriteAwait        *prometheus.GaugeVec
+	diskQueueSize         *prometheus.GaugeVec
 
 	// Net
 	netRxBytes   *prometheus.GaugeVec
@@ -56,6 +73,76 @@ type PrometheusExporter struct {
 	netTxErrors  *prometheus.GaugeVec
 	netRxDropped *prometheus.GaugeVec
 	netTxDropped *prometheus.GaugeVec
+	netInfo      *prometheus.GaugeVec
+	netCarrier   *prometheus.GaugeVec
+	netSpeed     *prometheus.GaugeVec
+	netMTU       *prometheus.GaugeVec
+	netUtil      *prometheus.GaugeVec
+
+	// TCP
+	tcpConnections     *prometheus.GaugeVec
+	tcpConnTotal       *prometheus.GaugeVec
+	sockstatSocketsUse *prometheus.GaugeVec
+	sockstatTCPInUse   *prometheus.GaugeVec
+	sockstatTCPOrphan  *prometheus.GaugeVec
+	sockstatTCPTW      *prometheus.GaugeVec
+	sockstatTCPAlloc   *prometheus.GaugeVec
+	sockstatTCPMem     *prometheus.GaugeVec
+	tcpRetransRatio    *prometheus.GaugeVec
+
+	// Limits
+	fileHandles  *prometheus.GaugeVec
+	kernelInodes *prometheus.GaugeVec
+	tasks        *prometheus.GaugeVec
+	tasksLimit   *prometheus.GaugeVec
+
+	// Sensors
+	sensorTemp      *prometheus.GaugeVec
+	sensorTempMax   *prometheus.GaugeVec
+	sensorTempCrit  *prometheus.GaugeVec
+	sensorFanRPM    *prometheus.GaugeVec
+	sensorFanMinRPM *prometheus.GaugeVec
+	sensorAlarm     *prometheus.GaugeVec
+
+	// Software RAID
+	mdInfo         *prometheus.GaugeVec
+	mdDisks        *prometheus.GaugeVec
+	mdDegraded     *prometheus.GaugeVec
+	mdSyncProgress *prometheus.GaugeVec
+
+	// 日志文件跟踪
+	logFileSize   *prometheus.GaugeVec
+	logFileOffset *prometheus.GaugeVec
+
+	// PSI
+	psiAvg *prometheus.GaugeVec
+
+	// Cgroup
+	cgroupCPUPercent     *prometheus.GaugeVec
+	cgroupCPUSeconds     *prometheus.GaugeVec
+	cgroupThrottledSecs  *prometheus.GaugeVec
+	cgroupMemCurrent     *prometheus.GaugeVec
+	cgroupMemMax         *prometheus.GaugeVec
+	cgroupOOMKills       *prometheus.GaugeVec
+	cgroupIOReadBytes    *prometheus.GaugeVec
+	cgroupIOWriteBytes   *prometheus.GaugeVec
+	cgroupPidsCurrent    *prometheus.GaugeVec
+	cgroupMemUsedPercent *prometheus.GaugeVec
+
+	// Process
+	procCPUPercent *prometheus.GaugeVec
+	procMemPercent *prometheus.GaugeVec
+	procRSSBytes   *prometheus.GaugeVec
+	procThreads    *prometheus.GaugeVec
+	procFDs        *prometheus.GaugeVec
+	procFDLimit    *prometheus.GaugeVec
+	procSockets    *prometheus.GaugeVec
+	procFDGrowth   *prometheus.GaugeVec
+
+	// Host
+	hostInfo *prometheus.GaugeVec
+	bootTime *prometheus.GaugeVec
+	uptime   *prometheus.GaugeVec
 
 	// alert
 	alertCount    *prometheus.GaugeVec
@@ -68,7 +155,8 @@ type PrometheusExporter struct {
 
 func NewPrometheusExporter(runner *engine.Runner) *PrometheusExporter {
 	e := &PrometheusExporter{
-		runner: runner,
+		runner:   runner,
+		textfile: &textfileCollector{},
 	}
 
 	// CPU
@@ -82,6 +170,31 @@ func NewPrometheusExporter(runner *engine.Runner) *PrometheusExporter {
 		Help: "每个 CPU 核心的使用率百分比",
 	}, []string{"host", "core"})
 
+	e.cpuMode = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cpu_mode_percent",
+		Help: "CPU 各模式（user/system/iowait/steal 等）时间占比",
+	}, []string{"host", "mode"})
+
+	e.cpuCoreMode = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cpu_core_mode_percent",
+		Help: "每个 CPU 核心各模式时间占比",
+	}, []string{"host", "core", "mode"})
+
+	e.cpuFreq = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cpu_frequency_hertz",
+		Help: "CPU 当前频率",
+	}, []string{"host", "cpu", "governor"})
+
+	e.cpuFreqMin = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cpu_frequency_min_hertz",
+		Help: "CPU 调频下限",
+	}, []string{"host", "cpu"})
+
+	e.cpuFreqMax = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cpu_frequency_max_hertz",
+		Help: "CPU 调频上限",
+	}, []string{"host", "cpu"})
+
 	e.loadAvg1 = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "system_load_avg_1min",
 		Help: "1 分钟平均负载",
@@ -143,7 +256,27 @@ func NewPrometheusExporter(runner *engine.Runner) *PrometheusExporter {
 		Help: "Swap 使用率百分比",
 	}, []string{"host"})
 
+	e.memDetail = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_memory_detail_bytes",
+		Help: "/proc/meminfo 明细(Bytes)",
+	}, []string{"host", "field"})
+
+	e.memHugePages = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_memory_hugepages",
+		Help: "预留大页数量",
+	}, []string{"host", "state"})
+
 	// Disk
+	e.diskInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_disk_info",
+		Help: "挂载点与块设备拓扑信息，值恒为 1",
+	}, []string{"host", "mount", "fstype", "device", "partition", "parent_disk", "dm_name"})
+
+	e.diskStale = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_disk_mount_stale",
+		Help: "挂载点是否无响应(statfs 超时)，1 为无响应",
+	}, []string{"host", "mount", "fstype"})
+
 	e.diskTotal = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "system_disk_total_bytes",
 		Help: "磁盘总容量",
@@ -204,6 +337,41 @@ func NewPrometheusExporter(runner *engine.Runner) *PrometheusExporter {
 		Help: "磁盘利用率百分比",
 	}, []string{"host", "device"})
 
+	e.diskReadIOPS = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_disk_read_iops",
+		Help: "磁盘每秒读完成次数(r/s)",
+	}, []string{"host", "device"})
+
+	e.diskWriteIOPS = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_disk_write_iops",
+		Help: "磁盘每秒写完成次数(w/s)",
+	}, []string{"host", "device"})
+
+	e.diskReadSpeed = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_disk_read_bytes_per_second",
+		Help: "磁盘读取速率",
+	}, []string{"host", "device"})
+
+	e.diskWriteSpeed = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_disk_write_bytes_per_second",
+		Help: "磁盘写入速率",
+	}, []string{"host", "device"})
+
+	e.diskReadAwait = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_disk_read_await_ms",
+		Help: "磁盘读平均耗时(毫秒)",
+	}, []string{"host", "device"})
+
+	e.diskWriteAwait = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_disk_write_await_ms",
+		Help: "磁盘写平均耗时(毫秒)",
+	}, []string{"host", "device"})
+
+	e.diskQueueSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_disk_avg_queue_size",
+		Help: "磁盘平均队列长度(aqu-sz)",
+	}, []string{"host", "device"})
+
 	// Network
 	e.netRxBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "system_network_receive_bytes_total",
@@ -245,6 +413,282 @@ func NewPrometheusExporter(runner *engine.Runner) *PrometheusExporter {
 		Help: "网络发送丢包总数",
 	}, []string{"host", "interface"})
 
+	e.netInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_network_info",
+		Help: "网卡元数据，值恒为 1",
+	}, []string{"host", "interface", "operstate", "duplex", "type", "virtual"})
+
+	e.netCarrier = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_network_carrier",
+		Help: "网卡物理链路状态(1=有载波)",
+	}, []string{"host", "interface"})
+
+	e.netSpeed = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_network_speed_bytes",
+		Help: "网卡协商速率(Bytes/s)",
+	}, []string{"host", "interface"})
+
+	e.netMTU = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_network_mtu_bytes",
+		Help: "网卡 MTU",
+	}, []string{"host", "interface"})
+
+	e.netUtil = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_network_utilization_percent",
+		Help: "网卡带宽使用率(占协商速率百分比)",
+	}, []string{"host", "interface", "direction"})
+
+	// TCP
+	e.tcpConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_tcp_connections",
+		Help: "各状态 TCP 连接数",
+	}, []string{"host", "state"})
+
+	e.tcpConnTotal = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_tcp_connections_total",
+		Help: "TCP 连接总数",
+	}, []string{"host"})
+
+	e.sockstatSocketsUse = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_sockstat_sockets_used",
+		Help: "已用套接字总数",
+	}, []string{"host"})
+
+	e.sockstatTCPInUse = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_sockstat_tcp_inuse",
+		Help: "正在使用的 TCP 套接字数",
+	}, []string{"host"})
+
+	e.sockstatTCPOrphan = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_sockstat_tcp_orphan",
+		Help: "孤儿 TCP 套接字数",
+	}, []string{"host"})
+
+	e.sockstatTCPTW = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_sockstat_tcp_tw",
+		Help: "TIME_WAIT 状态 TCP 套接字数",
+	}, []string{"host"})
+
+	e.sockstatTCPAlloc = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_sockstat_tcp_alloc",
+		Help: "已分配的 TCP 套接字数",
+	}, []string{"host"})
+
+	e.sockstatTCPMem = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_sockstat_tcp_mem_bytes",
+		Help: "TCP 缓冲区占用内存",
+	}, []string{"host"})
+
+	e.tcpRetransRatio = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_tcp_retrans_ratio_percent",
+		Help: "采集间隔内 TCP 重传报文占发送报文百分比",
+	}, []string{"host"})
+
+	// Limits
+	e.fileHandles = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_file_handles",
+		Help: "系统文件句柄数(allocated=已分配, max=fs.file-max)",
+	}, []string{"host", "state"})
+
+	e.kernelInodes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_kernel_inodes",
+		Help: "内核 inode 表对象数(allocated=已分配, free=空闲)",
+	}, []string{"host", "state"})
+
+	e.tasks = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_tasks",
+		Help: "调度实体(线程)数(running=可运行, total=总数)",
+	}, []string{"host", "state"})
+
+	e.tasksLimit = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_tasks_limit",
+		Help: "进程/线程数上限(pid_max, threads_max)",
+	}, []string{"host", "limit"})
+
+	// Sensors
+	e.sensorTemp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_sensor_temperature_celsius",
+		Help: "硬件温度传感器读数(°C)",
+	}, []string{"host", "chip", "device", "sensor", "label"})
+
+	e.sensorTempMax = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_sensor_temperature_max_celsius",
+		Help: "内核报告的温度上限(°C)",
+	}, []string{"host", "chip", "device", "sensor", "label"})
+
+	e.sensorTempCrit = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_sensor_temperature_crit_celsius",
+		Help: "内核报告的临界温度(°C)",
+	}, []string{"host", "chip", "device", "sensor", "label"})
+
+	e.sensorFanRPM = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_sensor_fan_rpm",
+		Help: "风扇转速(RPM)",
+	}, []string{"host", "chip", "device", "sensor", "label"})
+
+	e.sensorFanMinRPM = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_sensor_fan_min_rpm",
+		Help: "风扇最低转速阈值(RPM)",
+	}, []string{"host", "chip", "device", "sensor", "label"})
+
+	e.sensorAlarm = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_sensor_alarm",
+		Help: "传感器硬件告警位(1=告警)",
+	}, []string{"host", "chip", "device", "sensor", "label"})
+
+	// Software RAID
+	e.mdInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_md_info",
+		Help: "软 RAID 阵列信息，值恒为 1",
+	}, []string{"host", "device", "level", "state"})
+
+	e.mdDisks = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_md_disks",
+		Help: "软 RAID 成员数(expected/active/failed/spare)",
+	}, []string{"host", "device", "state"})
+
+	e.mdDegraded = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_md_degraded",
+		Help: "软 RAID 阵列是否降级(1=降级)",
+	}, []string{"host", "device"})
+
+	e.mdSyncProgress = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_md_sync_progress_percent",
+		Help: "软 RAID 同步/重建进度百分比",
+	}, []string{"host", "device", "action"})
+
+	// 日志文件跟踪
+	e.logFileSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_log_file_size_bytes",
+		Help: "跟踪的日志文件大小",
+	}, []string{"host", "path"})
+
+	e.logFileOffset = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_log_file_offset_bytes",
+		Help: "日志文件已读取到的位置，与文件大小的差值为积压量",
+	}, []string{"host", "path"})
+
+	// 协议栈、vmstat 等内核累计计数器
+	prometheus.MustRegister(counterCollector{e: e})
+	// 日志规则匹配计数，标签由规则的正则命名分组决定
+	prometheus.MustRegister(logMatchCollector{e: e})
+	// 外部程序写入的 *.prom 文件
+	prometheus.MustRegister(e.textfile)
+
+	// PSI
+	e.psiAvg = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_pressure_avg_percent",
+		Help: "PSI 资源压力停顿时间占比",
+	}, []string{"host", "resource", "type", "window"})
+
+	// Cgroup
+	e.cgroupCPUPercent = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cgroup_cpu_usage_percent",
+		Help: "cgroup CPU 使用率百分比",
+	}, []string{"host", "cgroup"})
+
+	e.cgroupCPUSeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cgroup_cpu_usage_seconds_total",
+		Help: "cgroup 累计 CPU 时间(秒)",
+	}, []string{"host", "cgroup"})
+
+	e.cgroupThrottledSecs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cgroup_cpu_throttled_seconds_total",
+		Help: "cgroup 累计被 CPU 配额限流时间(秒)",
+	}, []string{"host", "cgroup"})
+
+	e.cgroupMemCurrent = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cgroup_memory_current_bytes",
+		Help: "cgroup 当前内存用量",
+	}, []string{"host", "cgroup"})
+
+	e.cgroupMemMax = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cgroup_memory_max_bytes",
+		Help: "cgroup 内存上限，0 表示不限制",
+	}, []string{"host", "cgroup"})
+
+	e.cgroupMemUsedPercent = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cgroup_memory_used_percent",
+		Help: "cgroup 内存用量占上限百分比",
+	}, []string{"host", "cgroup"})
+
+	e.cgroupOOMKills = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cgroup_oom_kills_total",
+		Help: "cgroup 内被 OOM Killer 杀死的进程总数",
+	}, []string{"host", "cgroup"})
+
+	e.cgroupIOReadBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cgroup_io_read_bytes_total",
+		Help: "cgroup 磁盘读取字节总数",
+	}, []string{"host", "cgroup"})
+
+	e.cgroupIOWriteBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cgroup_io_write_bytes_total",
+		Help: "cgroup 磁盘写入字节总数",
+	}, []string{"host", "cgroup"})
+
+	e.cgroupPidsCurrent = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_cgroup_pids_current",
+		Help: "cgroup 当前进程/线程数",
+	}, []string{"host", "cgroup"})
+
+	// Process
+	e.procCPUPercent = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_process_cpu_percent",
+		Help: "TopN 进程 CPU 使用率百分比",
+	}, []string{"host", "pid", "name", "user"})
+
+	e.procMemPercent = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_process_memory_percent",
+		Help: "TopN 进程内存占用百分比",
+	}, []string{"host", "pid", "name", "user"})
+
+	e.procRSSBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_process_rss_bytes",
+		Help: "TopN 进程常驻内存",
+	}, []string{"host", "pid", "name", "user"})
+
+	e.procThreads = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_process_threads",
+		Help: "TopN 进程线程数",
+	}, []string{"host", "pid", "name", "user"})
+
+	e.procFDs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_process_open_fds",
+		Help: "TopN 进程打开的文件描述符数",
+	}, []string{"host", "pid", "name", "user"})
+
+	e.procFDLimit = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_process_max_fds",
+		Help: "TopN fd 进程的 RLIMIT_NOFILE 软限制",
+	}, []string{"host", "pid", "name", "user"})
+
+	e.procSockets = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_process_open_sockets",
+		Help: "TopN fd 进程打开的 socket 数",
+	}, []string{"host", "pid", "name", "user"})
+
+	e.procFDGrowth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_process_fd_growth",
+		Help: "TopN fd 进程在增长窗口内的 fd 数变化量",
+	}, []string{"host", "pid", "name", "user"})
+
+	// Host
+	e.hostInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_host_info",
+		Help: "主机清单信息，值恒为 1",
+	}, []string{"host", "kernel", "arch", "os_id", "os_version", "os_name", "machine_id", "cpu_model", "virtualization", "container"})
+
+	e.bootTime = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_boot_time_seconds",
+		Help: "系统启动时间(Unix 秒)",
+	}, []string{"host"})
+
+	e.uptime = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "system_uptime_seconds",
+		Help: "系统已运行时长",
+	}, []string{"host"})
+
 	// Alert
 	e.alertCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "tismin_alerts_triggered_total",
@@ -274,10 +718,13 @@ func (e *PrometheusExporter) StartMetricsCollector(ctx context.Context, interval
 }
 
 func (e *PrometheusExporter) collectMetrics() {
-	metrics, _, _ := e.runner.Snapshot()
+	metrics, errs, _ := e.runner.Snapshot()
 	if metrics == nil {
 		return
 	}
+	if errs == nil {
+		errs = &model.CollectErrors{}
+	}
 
 	e.mu.Lock()
 	e.metrics = metrics
@@ -288,6 +735,21 @@ func (e *PrometheusExporter) collectMetrics() {
 		host = "unknown"
 	}
 
+	e.textfile.update(host)
+
+	// Host - 内核升级或重启后标签会变化，先清理
+	// 采集失败时 Info 为空，不输出空标签的 host_info 和为 0 的启动时间
+	e.hostInfo.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.bootTime.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.uptime.DeletePartialMatch(prometheus.Labels{"host": host})
+	if len(errs.Info) == 0 {
+		info := metrics.Info
+		e.hostInfo.WithLabelValues(host, info.KernelRelease, info.Arch, info.OSID, info.OSVersion, info.OSName,
+			info.MachineID, info.CPUModel, info.Virtualization, info.Container).Set(1)
+		e.bootTime.WithLabelValues(host).Set(float64(info.BootTime))
+		e.uptime.WithLabelValues(host).Set(info.UptimeSeconds)
+	}
+
 	// CPU
 	e.cpuUsage.WithLabelValues(host).Set(metrics.CPU.UsagePercent)
 	e.loadAvg1.WithLabelValues(host).Set(metrics.CPU.Load1)
@@ -297,6 +759,23 @@ func (e *PrometheusExporter) collectMetrics() {
 	for i, coreUsage := range metrics.CPU.PerCPUUsage {
 		e.cpuCoresUsage.WithLabelValues(host, strconv.Itoa(i)).Set(coreUsage)
 	}
+	setCPUModes(e.cpuMode.MustCurryWith(prometheus.Labels{"host": host}), metrics.CPU.Modes)
+	for i, modes := range metrics.CPU.PerCPUModes {
+		setCPUModes(e.cpuCoreMode.MustCurryWith(prometheus.Labels{"host": host, "core": strconv.Itoa(i)}), modes)
+	}
+
+	// governor 可能在运行中被修改，先清理旧标签组合
+	e.cpuFreq.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.cpuFreqMin.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.cpuFreqMax.DeletePartialMatch(prometheus.Labels{"host": host})
+	for _, f := range metrics.CPUFreq {
+		if f.CurHz == 0 {
+			continue
+		}
+		e.cpuFreq.WithLabelValues(host, f.CPU, f.Governor).Set(float64(f.CurHz))
+		e.cpuFreqMin.WithLabelValues(host, f.CPU).Set(float64(f.MinHz))
+		e.cpuFreqMax.WithLabelValues(host, f.CPU).Set(float64(f.MaxHz))
+	}
 
 	// Memory
 	e.memTotal.WithLabelValues(host).Set(float64(metrics.Mem.Total))
@@ -310,7 +789,38 @@ func (e *PrometheusExporter) collectMetrics() {
 	e.swapUsed.WithLabelValues(host).Set(float64(metrics.Mem.SwapUsed))
 	e.swapUsedPercent.WithLabelValues(host).Set(metrics.Mem.SwapUsedPercent)
 
+	// meminfo 读取失败时明细均为 0，不输出以免被误读
+	e.memDetail.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.memHugePages.DeletePartialMatch(prometheus.Labels{"host": host})
+	if len(errs.Mem) == 0 {
+		mem := metrics.Mem
+		for field, v := range map[string]uint64{
+			"buffers":         mem.Buffers,
+			"cached":          mem.Cached,
+			"dirty":           mem.Dirty,
+			"writeback":       mem.Writeback,
+			"mapped":          mem.Mapped,
+			"shmem":           mem.Shmem,
+			"slab":            mem.Slab,
+			"sreclaimable":    mem.SReclaimable,
+			"sunreclaim":      mem.SUnreclaim,
+			"page_tables":     mem.PageTables,
+			"commit_limit":    mem.CommitLimit,
+			"committed_as":    mem.CommittedAS,
+			"anon_huge_pages": mem.AnonHugePages,
+			"hugepage_size":   mem.HugePageSize,
+		} {
+			e.memDetail.WithLabelValues(host, field).Set(float64(v))
+		}
+		e.memHugePages.WithLabelValues(host, "total").Set(float64(mem.HugePagesTotal))
+		e.memHugePages.WithLabelValues(host, "free").Set(float64(mem.HugePagesFree))
+		e.memHugePages.WithLabelValues(host, "reserved").Set(float64(mem.HugePagesRsvd))
+		e.memHugePages.WithLabelValues(host, "surplus").Set(float64(mem.HugePagesSurp))
+	}
+
 	// Disk - 清理旧指标
+	e.diskInfo.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.diskStale.DeletePartialMatch(prometheus.Labels{"host": host})
 	e.diskTotal.DeletePartialMatch(prometheus.Labels{"host": host})
 	e.diskFree.DeletePartialMatch(prometheus.Labels{"host": host})
 	e.diskUsed.DeletePartialMatch(prometheus.Labels{"host": host})
@@ -323,11 +833,27 @@ func (e *PrometheusExporter) collectMetrics() {
 	e.diskWriteBytes.DeletePartialMatch(prometheus.Labels{"host": host})
 	e.diskAwait.DeletePartialMatch(prometheus.Labels{"host": host})
 	e.diskUtil.DeletePartialMatch(prometheus.Labels{"host": host})
-
+	e.diskReadIOPS.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.diskWriteIOPS.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.diskReadSpeed.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.diskWriteSpeed.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.diskReadAwait.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.diskWriteAwait.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.diskQueueSize.DeletePartialMatch(prometheus.Labels{"host": host})
+
+	// 同一设备可能有多个挂载点（bind 挂载、btrfs 子卷），IO 指标按设备只输出一次
+	ioSeen := make(map[string]bool)
 	for _, disk := range metrics.Disk {
 		mount := disk.MountPoint
 		device := disk.Device
 
+		e.diskInfo.WithLabelValues(host, mount, disk.FSType, device, disk.Partition, disk.ParentDisk, disk.DMName).Set(1)
+		if disk.Status == model.DiskStatusStale {
+			// 无响应的挂载点没有容量数据，避免输出误导性的 0 值
+			e.diskStale.WithLabelValues(host, mount, disk.FSType).Set(1)
+			continue
+		}
+		e.diskStale.WithLabelValues(host, mount, disk.FSType).Set(0)
 		e.diskTotal.WithLabelValues(host, mount).Set(float64(disk.Total))
 		e.diskFree.WithLabelValues(host, mount).Set(float64(disk.Free))
 		e.diskUsed.WithLabelValues(host, mount).Set(float64(disk.Used))
@@ -338,10 +864,22 @@ func (e *PrometheusExporter) collectMetrics() {
 		e.diskInodesFree.WithLabelValues(host, mount).Set(float64(disk.InodesFree))
 		e.diskInodesUsedPercent.WithLabelValues(host, mount).Set(disk.InodesUsedPercent)
 
+		// 网络文件系统没有块设备，不输出 IO 指标
+		if device == "" || ioSeen[device] {
+			continue
+		}
+		ioSeen[device] = true
 		e.diskReadBytes.WithLabelValues(host, device).Set(float64(disk.Read))
 		e.diskWriteBytes.WithLabelValues(host, device).Set(float64(disk.Write))
 		e.diskAwait.WithLabelValues(host, device).Set(disk.Await)
 		e.diskUtil.WithLabelValues(host, device).Set(disk.Util)
+		e.diskReadIOPS.WithLabelValues(host, device).Set(disk.ReadIOPS)
+		e.diskWriteIOPS.WithLabelValues(host, device).Set(disk.WriteIOPS)
+		e.diskReadSpeed.WithLabelValues(host, device).Set(disk.ReadSpeed)
+		e.diskWriteSpeed.WithLabelValues(host, device).Set(disk.WriteSpeed)
+		e.diskReadAwait.WithLabelValues(host, device).Set(disk.ReadAwait)
+		e.diskWriteAwait.WithLabelValues(host, device).Set(disk.WriteAwait)
+		e.diskQueueSize.WithLabelValues(host, device).Set(disk.AvgQueueSize)
 	}
 
 	// Network - 清理旧指标
@@ -353,6 +891,11 @@ func (e *PrometheusExporter) collectMetrics() {
 	e.netTxErrors.DeletePartialMatch(prometheus.Labels{"host": host})
 	e.netRxDropped.DeletePartialMatch(prometheus.Labels{"host": host})
 	e.netTxDropped.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.netInfo.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.netCarrier.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.netSpeed.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.netMTU.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.netUtil.DeletePartialMatch(prometheus.Labels{"host": host})
 
 	for _, net := range metrics.Net {
 		iface := net.Name
@@ -365,7 +908,216 @@ func (e *PrometheusExporter) collectMetrics() {
 		e.netTxErrors.WithLabelValues(host, iface).Set(float64(net.TxErrors))
 		e.netRxDropped.WithLabelValues(host, iface).Set(float64(net.RxDropped))
 		e.netTxDropped.WithLabelValues(host, iface).Set(float64(net.TxDropped))
+
+		e.netInfo.WithLabelValues(host, iface, net.OperState, net.Duplex, net.Type, strconv.FormatBool(net.Virtual)).Set(1)
+		carrier := 0.0
+		if net.Carrier {
+			carrier = 1
+		}
+		e.netCarrier.WithLabelValues(host, iface).Set(carrier)
+		e.netMTU.WithLabelValues(host, iface).Set(float64(net.MTU))
+		// 速率未知（虚拟网卡、链路 down）时不输出速率与使用率
+		if net.SpeedMbps > 0 {
+			e.netSpeed.WithLabelValues(host, iface).Set(float64(net.SpeedMbps) * 1e6 / 8)
+			e.netUtil.WithLabelValues(host, iface, "receive").Set(net.RxUtilization)
+			e.netUtil.WithLabelValues(host, iface, "transmit").Set(net.TxUtilization)
+		}
+	}
+
+	// TCP - 读取失败时不输出，避免连接数与 sockstat 出现 0 值
+	e.tcpConnections.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.tcpConnTotal.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.sockstatSocketsUse.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.sockstatTCPInUse.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.sockstatTCPOrphan.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.sockstatTCPTW.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.sockstatTCPAlloc.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.sockstatTCPMem.DeletePartialMatch(prometheus.Labels{"host": host})
+	if len(errs.TCP) == 0 {
+		for state, count := range metrics.TCP.States {
+			e.tcpConnections.WithLabelValues(host, state).Set(float64(count))
+		}
+		e.tcpConnTotal.WithLabelValues(host).Set(float64(metrics.TCP.Total))
+		e.sockstatSocketsUse.WithLabelValues(host).Set(float64(metrics.TCP.SocketsUsed))
+		e.sockstatTCPInUse.WithLabelValues(host).Set(float64(metrics.TCP.InUse))
+		e.sockstatTCPOrphan.WithLabelValues(host).Set(float64(metrics.TCP.Orphan))
+		e.sockstatTCPTW.WithLabelValues(host).Set(float64(metrics.TCP.TimeWaitSockets))
+		e.sockstatTCPAlloc.WithLabelValues(host).Set(float64(metrics.TCP.Alloc))
+		e.sockstatTCPMem.WithLabelValues(host).Set(float64(metrics.TCP.MemBytes))
+	}
+
+	// 重传率基于上一轮计算，首轮或读取失败时没有速率，不输出 0 值
+	e.tcpRetransRatio.DeletePartialMatch(prometheus.Labels{"host": host})
+	if metrics.Proto.Rates != nil {
+		e.tcpRetransRatio.WithLabelValues(host).Set(metrics.Proto.TCPRetransRatio)
 	}
+
+	// Limits - 读取失败时不输出 0 值，避免使用率计算出错
+	e.fileHandles.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.kernelInodes.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.tasks.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.tasksLimit.DeletePartialMatch(prometheus.Labels{"host": host})
+	if len(errs.Limits) == 0 {
+		limits := metrics.Limits
+		e.fileHandles.WithLabelValues(host, "allocated").Set(float64(limits.FileHandlesAllocated))
+		e.fileHandles.WithLabelValues(host, "max").Set(float64(limits.FileHandlesMax))
+		e.kernelInodes.WithLabelValues(host, "allocated").Set(float64(limits.InodesAllocated))
+		e.kernelInodes.WithLabelValues(host, "free").Set(float64(limits.InodesFree))
+		e.tasks.WithLabelValues(host, "running").Set(float64(limits.TasksRunning))
+		e.tasks.WithLabelValues(host, "total").Set(float64(limits.TasksTotal))
+		e.tasksLimit.WithLabelValues(host, "pid_max").Set(float64(limits.PIDMax))
+		e.tasksLimit.WithLabelValues(host, "threads_max").Set(float64(limits.ThreadsMax))
+	}
+
+	// PSI - 内核不支持或读取失败时清理旧值；累计停顿时间由 counterCollector 输出
+	e.psiAvg.DeletePartialMatch(prometheus.Labels{"host": host})
+	if metrics.PSI.Available {
+		for resource, res := range map[string]model.PSIResource{
+			"cpu":    metrics.PSI.CPU,
+			"memory": metrics.PSI.Memory,
+			"io":     metrics.PSI.IO,
+		} {
+			for typ, line := range map[string]model.PSILine{"some": res.Some, "full": res.Full} {
+				e.psiAvg.WithLabelValues(host, resource, typ, "10s").Set(line.Avg10)
+				e.psiAvg.WithLabelValues(host, resource, typ, "60s").Set(line.Avg60)
+				e.psiAvg.WithLabelValues(host, resource, typ, "300s").Set(line.Avg300)
+			}
+		}
+	}
+
+	// Cgroup - 清理旧指标（容器和服务会被创建/销毁）
+	e.cgroupCPUPercent.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.cgroupCPUSeconds.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.cgroupThrottledSecs.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.cgroupMemCurrent.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.cgroupMemMax.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.cgroupMemUsedPercent.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.cgroupOOMKills.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.cgroupIOReadBytes.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.cgroupIOWriteBytes.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.cgroupPidsCurrent.DeletePartialMatch(prometheus.Labels{"host": host})
+
+	for _, cg := range metrics.Cgroups {
+		e.cgroupCPUPercent.WithLabelValues(host, cg.Path).Set(cg.CPUUsagePercent)
+		e.cgroupCPUSeconds.WithLabelValues(host, cg.Path).Set(float64(cg.CPUUsageUsec) / 1e6)
+		e.cgroupThrottledSecs.WithLabelValues(host, cg.Path).Set(float64(cg.ThrottledUsec) / 1e6)
+		e.cgroupMemCurrent.WithLabelValues(host, cg.Path).Set(float64(cg.MemoryCurrent))
+		e.cgroupMemMax.WithLabelValues(host, cg.Path).Set(float64(cg.MemoryMax))
+		e.cgroupMemUsedPercent.WithLabelValues(host, cg.Path).Set(cg.MemoryUsedPercent)
+		e.cgroupOOMKills.WithLabelValues(host, cg.Path).Set(float64(cg.OOMKills))
+		e.cgroupIOReadBytes.WithLabelValues(host, cg.Path).Set(float64(cg.IOReadBytes))
+		e.cgroupIOWriteBytes.WithLabelValues(host, cg.Path).Set(float64(cg.IOWriteBytes))
+		e.cgroupPidsCurrent.WithLabelValues(host, cg.Path).Set(float64(cg.PidsCurrent))
+	}
+
+	// Sensors - 清理旧指标
+	e.sensorTemp.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.sensorTempMax.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.sensorTempCrit.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.sensorFanRPM.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.sensorFanMinRPM.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.sensorAlarm.DeletePartialMatch(prometheus.Labels{"host": host})
+
+	for _, sensor := range metrics.Sensors {
+		labels := []string{host, sensor.Chip, sensor.Device, sensor.Sensor, sensor.Label}
+		alarm := 0.0
+		if sensor.Alarm {
+			alarm = 1
+		}
+		e.sensorAlarm.WithLabelValues(labels...).Set(alarm)
+
+		switch sensor.Type {
+		case model.SensorTemperature:
+			e.sensorTemp.WithLabelValues(labels...).Set(sensor.Value)
+			if sensor.Max > 0 {
+				e.sensorTempMax.WithLabelValues(labels...).Set(sensor.Max)
+			}
+			if sensor.Crit > 0 {
+				e.sensorTempCrit.WithLabelValues(labels...).Set(sensor.Crit)
+			}
+		case model.SensorFan:
+			e.sensorFanRPM.WithLabelValues(labels...).Set(sensor.Value)
+			if sensor.Min > 0 {
+				e.sensorFanMinRPM.WithLabelValues(labels...).Set(sensor.Min)
+			}
+		}
+	}
+
+	// Software RAID - 清理旧指标
+	e.mdInfo.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.mdDisks.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.mdDegraded.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.mdSyncProgress.DeletePartialMatch(prometheus.Labels{"host": host})
+
+	for _, md := range metrics.MD {
+		e.mdInfo.WithLabelValues(host, md.Name, md.Level, md.State).Set(1)
+		e.mdDisks.WithLabelValues(host, md.Name, "expected").Set(float64(md.DisksTotal))
+		e.mdDisks.WithLabelValues(host, md.Name, "active").Set(float64(md.DisksActive))
+		e.mdDisks.WithLabelValues(host, md.Name, "failed").Set(float64(md.Failed))
+		e.mdDisks.WithLabelValues(host, md.Name, "spare").Set(float64(md.Spare))
+		degraded := 0.0
+		if md.Degraded {
+			degraded = 1
+		}
+		e.mdDegraded.WithLabelValues(host, md.Name).Set(degraded)
+		if md.SyncAction != "" {
+			e.mdSyncProgress.WithLabelValues(host, md.Name, md.SyncAction).Set(md.SyncPercent)
+		}
+	}
+
+	// 日志文件 - 清理旧指标（文件会被轮转删除）
+	e.logFileSize.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.logFileOffset.DeletePartialMatch(prometheus.Labels{"host": host})
+
+	for _, f := range metrics.LogFiles {
+		e.logFileSize.WithLabelValues(host, f.Path).Set(float64(f.Size))
+		e.logFileOffset.WithLabelValues(host, f.Path).Set(float64(f.Offset))
+	}
+
+	// Process - 清理旧指标（进程会退出，TopN 名单每轮都会变化）
+	e.procCPUPercent.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.procMemPercent.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.procRSSBytes.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.procThreads.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.procFDs.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.procFDLimit.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.procSockets.DeletePartialMatch(prometheus.Labels{"host": host})
+	e.procFDGrowth.DeletePartialMatch(prometheus.Labels{"host": host})
+
+	for _, list := range [][]model.ProcStat{metrics.Procs, metrics.ProcsByMem} {
+		for _, proc := range list {
+			pid := strconv.Itoa(proc.PID)
+
+			e.procCPUPercent.WithLabelValues(host, pid, proc.Name, proc.User).Set(proc.CPU)
+			e.procMemPercent.WithLabelValues(host, pid, proc.Name, proc.User).Set(proc.Mem)
+			e.procRSSBytes.WithLabelValues(host, pid, proc.Name, proc.User).Set(float64(proc.RSS))
+			e.procThreads.WithLabelValues(host, pid, proc.Name, proc.User).Set(float64(proc.Threads))
+			if proc.FDs > 0 {
+				e.procFDs.WithLabelValues(host, pid, proc.Name, proc.User).Set(float64(proc.FDs))
+			}
+		}
+	}
+	for _, proc := range metrics.ProcsByFD {
+		pid := strconv.Itoa(proc.PID)
+
+		e.procFDs.WithLabelValues(host, pid, proc.Name, proc.User).Set(float64(proc.FDs))
+		e.procSockets.WithLabelValues(host, pid, proc.Name, proc.User).Set(float64(proc.Sockets))
+		e.procFDGrowth.WithLabelValues(host, pid, proc.Name, proc.User).Set(float64(proc.FDGrowth))
+		if proc.FDSoftLimit > 0 {
+			e.procFDLimit.WithLabelValues(host, pid, proc.Name, proc.User).Set(float64(proc.FDSoftLimit))
+		}
+	}
+}
+
+func setCPUModes(g *prometheus.GaugeVec, modes model.CPUModes) {
+	g.WithLabelValues("user").Set(modes.User)
+	g.WithLabelValues("nice").Set(modes.Nice)
+	g.WithLabelValues("system").Set(modes.System)
+	g.WithLabelValues("idle").Set(modes.Idle)
+	g.WithLabelValues("iowait").Set(modes.IOWait)
+	g.WithLabelValues("irq").Set(modes.IRQ)
+	g.WithLabelValues("softirq").Set(modes.SoftIRQ)
+	g.WithLabelValues("steal").Set(modes.Steal)
 }
 
 func (e *PrometheusExporter) RecordAlert(count int) {
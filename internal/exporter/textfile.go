@@ -0,0 +1,266 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	prommodel "github.com/prometheus/common/model"
+)
+
+// 与本程序及 client_golang 默认指标重名会导致整个 /metrics 抓取失败，不允许外部文件使用
+var textfileReservedPrefixes = []string{"system_", "tismin_", "go_", "process_", "promhttp_"}
+
+var (
+	textfileMtimeDesc = prometheus.NewDesc(
+		"system_textfile_mtime_seconds",
+		"textfile 文件的修改时间 (Unix 秒)，用于发现长时间未更新的文件",
+		[]string{"host", "file"}, nil,
+	)
+	textfileErrorDesc = prometheus.NewDesc(
+		"system_textfile_scrape_error",
+		"textfile 文件解析或校验是否失败(1=失败，该文件的指标不输出)",
+		[]string{"host", "file"}, nil,
+	)
+)
+
+// textfileCollector 读取外部程序（如 cron 任务）写入目录的 *.prom 文件，
+// 每个采集周期解析一次，抓取时输出缓存结果，所有指标附加 host 与 file 标签。
+// 文件由外部写入，标签在运行时才确定，因此 Describe 不声明描述符，作为 unchecked collector 注册
+type textfileCollector struct {
+	mu      sync.RWMutex
+	dir     string
+	metrics []prometheus.Metric
+	errs    map[string]string // 上一轮各文件的错误，只在变化时打印日志
+}
+
+// textfileFamily 合并多个文件后的同名指标
+type textfileFamily struct {
+	help    string
+	typ     dto.MetricType
+	labels  []string // 所有文件中出现过的标签并集，不含 host、file
+	metrics []textfileMetric
+}
+
+type textfileMetric struct {
+	file string
+	m    *dto.Metric
+}
+
+func (c *textfileCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (c *textfileCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, m := range c.metrics {
+		ch <- m
+	}
+}
+
+// SetTextfileDir 设置 textfile 目录，为空时不读取
+func (e *PrometheusExporter) SetTextfileDir(dir string) {
+	e.textfile.mu.Lock()
+	e.textfile.dir = dir
+	e.textfile.mu.Unlock()
+}
+
+// update 重新读取目录下的 *.prom 文件
+// 单个文件解析或校验失败时整体丢弃该文件，不影响其他文件
+func (c *textfileCollector) update(host string) {
+	c.mu.RLock()
+	dir := c.dir
+	c.mu.RUnlock()
+	if dir == "" {
+		return
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.prom"))
+	sort.Strings(paths)
+
+	var out []prometheus.Metric
+	families := make(map[string]*textfileFamily)
+	errs := make(map[string]string)
+	for _, path := range paths {
+		file := filepath.Base(path)
+		// 文件名作为 file 标签值，不是合法 UTF-8 时该文件的指标都无法输出，只记录日志
+		if !utf8.ValidString(file) {
+			errs[file] = "file name is not valid UTF-8"
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if m, err := prometheus.NewConstMetric(textfileMtimeDesc, prometheus.GaugeValue, float64(fi.ModTime().UnixNano())/1e9, host, file); err == nil {
+			out = append(out, m)
+		}
+
+		parsed, err := parseTextfile(path)
+		if err == nil {
+			err = mergeTextfile(families, file, parsed)
+		}
+		failed := 0.0
+		if err != nil {
+			failed = 1
+			errs[file] = err.Error()
+		}
+		if m, err := prometheus.NewConstMetric(textfileErrorDesc, prometheus.GaugeValue, failed, host, file); err == nil {
+			out = append(out, m)
+		}
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		out = append(out, families[name].constMetrics(name, host)...)
+	}
+
+	c.mu.Lock()
+	c.metrics = out
+	c.logErrors(errs)
+	c.mu.Unlock()
+}
+
+func (c *textfileCollector) logErrors(errs map[string]string) {
+	for file, msg := range errs {
+		if c.errs[file] != msg {
+			log.Printf("textfile %q ignored: %s", file, msg)
+		}
+	}
+	c.errs = errs
+}
+
+func parseTextfile(path string) (map[string]*dto.MetricFamily, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	parser := expfmt.NewTextParser(prommodel.LegacyValidation)
+	families, err := parser.TextToMetricFamilies(f)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, mf := range families {
+		for _, prefix := range textfileReservedPrefixes {
+			if strings.HasPrefix(name, prefix) {
+				return nil, fmt.Errorf("metric %s uses reserved prefix %q", name, prefix)
+			}
+		}
+		seen := make(map[string]bool, len(mf.GetMetric()))
+		for _, m := range mf.GetMetric() {
+			// 带时间戳的样本在合并输出后语义不明确，与 node_exporter 一致直接拒绝
+			if m.TimestampMs != nil {
+				return nil, fmt.Errorf("metric %s has explicit timestamp", name)
+			}
+			pairs := make([]string, 0, len(m.GetLabel()))
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "host" || l.GetName() == "file" {
+					return nil, fmt.Errorf("metric %s uses reserved label %q", name, l.GetName())
+				}
+				pairs = append(pairs, l.GetName()+"="+l.GetValue())
+			}
+			sort.Strings(pairs)
+			key := strings.Join(pairs, ",")
+			if seen[key] {
+				return nil, fmt.Errorf("duplicate series %s{%s}", name, key)
+			}
+			seen[key] = true
+		}
+	}
+	return families, nil
+}
+
+// mergeTextfile 将单个文件的指标合并到 families，与已有同名指标类型不一致时整个文件不合并
+func mergeTextfile(families map[string]*textfileFamily, file string, parsed map[string]*dto.MetricFamily) error {
+	for name, mf := range parsed {
+		if tf, ok := families[name]; ok && tf.typ != mf.GetType() {
+			return fmt.Errorf("metric %s type %s conflicts with %s in other files", name, mf.GetType(), tf.typ)
+		}
+	}
+	for name, mf := range parsed {
+		tf, ok := families[name]
+		if !ok {
+			help := mf.GetHelp()
+			if help == "" {
+				help = "Metric read from textfile " + file
+			}
+			tf = &textfileFamily{help: help, typ: mf.GetType()}
+			families[name] = tf
+		}
+		for _, m := range mf.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if !slices.Contains(tf.labels, l.GetName()) {
+					tf.labels = append(tf.labels, l.GetName())
+				}
+			}
+			tf.metrics = append(tf.metrics, textfileMetric{file: file, m: m})
+		}
+	}
+	return nil
+}
+
+// constMetrics 按标签并集输出，文件中没有的标签取空值，保证同名指标的标签维度一致
+func (tf *textfileFamily) constMetrics(name, host string) []prometheus.Metric {
+	sort.Strings(tf.labels)
+	desc := prometheus.NewDesc(name, tf.help, append([]string{"host", "file"}, tf.labels...), nil)
+
+	ret := make([]prometheus.Metric, 0, len(tf.metrics))
+	for _, tm := range tf.metrics {
+		values := make([]string, 0, len(tf.labels)+2)
+		values = append(values, host, tm.file)
+		for _, label := range tf.labels {
+			v := ""
+			for _, l := range tm.m.GetLabel() {
+				if l.GetName() == label {
+					v = l.GetValue()
+					break
+				}
+			}
+			values = append(values, v)
+		}
+
+		var metric prometheus.Metric
+		var err error
+		m := tm.m
+		switch tf.typ {
+		case dto.MetricType_COUNTER:
+			metric, err = prometheus.NewConstMetric(desc, prometheus.CounterValue, m.GetCounter().GetValue(), values...)
+		case dto.MetricType_GAUGE:
+			metric, err = prometheus.NewConstMetric(desc, prometheus.GaugeValue, m.GetGauge().GetValue(), values...)
+		case dto.MetricType_SUMMARY:
+			quantiles := make(map[float64]float64, len(m.GetSummary().GetQuantile()))
+			for _, q := range m.GetSummary().GetQuantile() {
+				quantiles[q.GetQuantile()] = q.GetValue()
+			}
+			metric, err = prometheus.NewConstSummary(desc, m.GetSummary().GetSampleCount(), m.GetSummary().GetSampleSum(), quantiles, values...)
+		case dto.MetricType_HISTOGRAM:
+			buckets := make(map[float64]uint64, len(m.GetHistogram().GetBucket()))
+			for _, b := range m.GetHistogram().GetBucket() {
+				buckets[b.GetUpperBound()] = b.GetCumulativeCount()
+			}
+			metric, err = prometheus.NewConstHistogram(desc, m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum(), buckets, values...)
+		default:
+			metric, err = prometheus.NewConstMetric(desc, prometheus.UntypedValue, m.GetUntyped().GetValue(), values...)
+		}
+		if err != nil {
+			continue
+		}
+		ret = append(ret, metric)
+	}
+	return ret
+}
@@ -0,0 +1,126 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func writeTextfile(t *testing.T, dir, name, data string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseTextfile(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"valid", "# TYPE backup_ok gauge\nbackup_ok{job=\"db\"} 1\nbackup_ok{job=\"web\"} 0\n", ""},
+		{"reserved prefix", "system_cpu_usage_percent 1\n", "reserved prefix"},
+		{"timestamp", "backup_ok 1 1700000000000\n", "explicit timestamp"},
+		{"host label", "backup_ok{host=\"a\"} 1\n", "reserved label \"host\""},
+		{"file label", "backup_ok{file=\"a\"} 1\n", "reserved label \"file\""},
+		{"duplicate series", "backup_ok{job=\"db\",env=\"prod\"} 1\nbackup_ok{env=\"prod\",job=\"db\"} 0\n", "duplicate series"},
+		{"syntax error", "backup_ok{job=\"db\" 1\n", "text format parsing error"},
+	}
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTextfile(t, dir, "test.prom", tt.data)
+			_, err := parseTextfile(path)
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("parseTextfile() error = %v; want nil", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("parseTextfile() error = %v; want containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestTextfileUpdate(t *testing.T) {
+	dir := t.TempDir()
+	writeTextfile(t, dir, "a.prom", "# HELP jobs_total Jobs processed.\n# TYPE jobs_total counter\njobs_total{job=\"a\"} 3\n")
+	writeTextfile(t, dir, "b.prom", "# TYPE jobs_total counter\njobs_total{job=\"b\",queue=\"x\"} 5\n")
+	// 与 a.prom 类型冲突，整个文件丢弃
+	writeTextfile(t, dir, "c.prom", "# TYPE jobs_total gauge\njobs_total 1\n# TYPE other_metric gauge\nother_metric 1\n")
+	// 文件名不是合法 UTF-8，无法作为 file 标签值
+	writeTextfile(t, dir, "bad\xff.prom", "bad_name_metric 1\n")
+	writeTextfile(t, dir, "ignored.txt", "ignored_metric 1\n")
+
+	c := &textfileCollector{dir: dir}
+	c.update("node1")
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Fatal(err)
+	}
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string][]string)
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			got[mf.GetName()] = append(got[mf.GetName()], seriesString(m))
+		}
+	}
+	// mtime 随文件变化，只检查输出了哪些文件
+	for i, s := range got["system_textfile_mtime_seconds"] {
+		got["system_textfile_mtime_seconds"][i] = s[:strings.LastIndex(s, " ")]
+	}
+	want := map[string][]string{
+		"jobs_total": {
+			`file="a.prom",host="node1",job="a",queue="" 3`,
+			`file="b.prom",host="node1",job="b",queue="x" 5`,
+		},
+		"system_textfile_mtime_seconds": {
+			`file="a.prom",host="node1"`,
+			`file="b.prom",host="node1"`,
+			`file="c.prom",host="node1"`,
+		},
+		"system_textfile_scrape_error": {
+			`file="a.prom",host="node1" 0`,
+			`file="b.prom",host="node1" 0`,
+			`file="c.prom",host="node1" 1`,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("gathered metrics mismatch\n got: %v\nwant: %v", got, want)
+	}
+	if len(c.errs) != 2 || c.errs["c.prom"] == "" || c.errs["bad\xff.prom"] == "" {
+		t.Errorf("errs = %q; want errors for c.prom and the non-UTF-8 file", c.errs)
+	}
+}
+
+// seriesString 以 label="value",... value 的形式表示一个样本，标签按名字排序
+func seriesString(m *dto.Metric) string {
+	pairs := make([]string, 0, len(m.GetLabel()))
+	for _, l := range m.GetLabel() {
+		pairs = append(pairs, l.GetName()+"=\""+l.GetValue()+"\"")
+	}
+	sort.Strings(pairs)
+	var v float64
+	switch {
+	case m.Counter != nil:
+		v = m.GetCounter().GetValue()
+	case m.Gauge != nil:
+		v = m.GetGauge().GetValue()
+	default:
+		v = m.GetUntyped().GetValue()
+	}
+	return strings.Join(pairs, ",") + " " + strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -2,15 +2,30 @@ package model
 
 // CollectErrors aggregates partial collection errors by subsystem.
 type CollectErrors struct {
-	CPU  []error
-	Mem  []error
-	Disk []error
-	Net  []error
+	CPU     []error
+	Mem     []error
+	VM      []error
+	Disk    []error
+	Net     []error
+	Proc    []error
+	TCP     []error
+	Proto   []error
+	Limits  []error
+	Sensors []error
+	MD      []error
+	NFS     []error
+	CPUFreq []error
+	IRQ     []error
+	Info    []error
+	PSI     []error
+	Cgroup  []error
+	Kernel  []error
+	Logs    []error
 }
 
 func (e *CollectErrors) HasError() bool {
 	if e == nil {
 		return false
 	}
-	return len(e.CPU)+len(e.Mem)+len(e.Disk)+len(e.Net) > 0
+	return len(e.CPU)+len(e.Mem)+len(e.VM)+len(e.Disk)+len(e.Net)+len(e.Proc)+len(e.TCP)+len(e.Proto)+len(e.Limits)+len(e.Sensors)+len(e.MD)+len(e.NFS)+len(e.CPUFreq)+len(e.IRQ)+len(e.Info)+len(e.PSI)+len(e.Cgroup)+len(e.Kernel)+len(e.Logs) > 0
 }
@@ -9,6 +9,7 @@ type Config struct {
 	Diagnostic DiagnosticConfig `mapstructure:"diagnostic"`
 	Alert      AlertConfig      `mapstructure:"alert"`
 	Email      EmailConfig      `mapstructure:"email"`
+	Collector  CollectorConfig  `mapstructure:"collector"`
 }
 type Appconfig struct {
 	Name            string        `mapstructure:"name"`
@@ -16,6 +17,84 @@ type Appconfig struct {
 	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
 	LogLevel        string        `mapstructure:"loglevel"`
 	LogPath         string        `mapstructure:"log_path"`
+	StateDir        string        `mapstructure:"state_dir"` // 持久化状态目录（上次启动信息等），为空时不持久化
+}
+
+// CollectorConfig 采集器的数据源位置，容器内运行时指向挂载进来的宿主机目录
+type CollectorConfig struct {
+	ProcRoot string `mapstructure:"proc_root"` // procfs 根目录，默认 /proc
+	SysRoot  string `mapstructure:"sys_root"`  // sysfs 根目录，默认 /sys
+	RootFS   string `mapstructure:"rootfs"`    // 宿主机根文件系统，statfs 挂载点时使用，默认 /
+
+	StatfsTimeout time.Duration `mapstructure:"statfs_timeout"` // 单个挂载点 statfs 超时，从发起调用起计算，超时的挂载点标记为 stale，默认 2s
+	Debug         bool          `mapstructure:"debug"`          // 输出被过滤的挂载点等调试信息，app.loglevel 为 debug 时自动开启
+
+	Disk DiskFilterConfig `mapstructure:"disk"`
+	Net  NetFilterConfig  `mapstructure:"net"`
+
+	ProcessTopN int      `mapstructure:"process_top_n"` // 进程 TopN 数量，未配置时沿用 diagnostic.show_top_n_list
+	FD          FDConfig `mapstructure:"fd"`
+
+	Cgroup CgroupConfig `mapstructure:"cgroup"`
+
+	Kmsg KmsgConfig    `mapstructure:"kmsg"`
+	Logs LogTailConfig `mapstructure:"logs"`
+
+	StateDir string `mapstructure:"-"` // 持久化状态目录，沿用 app.state_dir，用于保存日志读取位置
+}
+
+// DiskFilterConfig 磁盘采集过滤规则，同时作用于容量与 IO 采集
+// 挂载点与设备规则支持 glob、以 /** 结尾的子树匹配以及 regex: 前缀的正则
+// 命中任一 include 规则的挂载点总会被采集，优先级高于 exclude
+type DiskFilterConfig struct {
+	IncludeFSTypes     []string `mapstructure:"include_fs_types"`
+	ExcludeFSTypes     []string `mapstructure:"exclude_fs_types"` // 为空时使用内置的虚拟文件系统列表
+	IncludeMountPoints []string `mapstructure:"include_mount_points"`
+	ExcludeMountPoints []string `mapstructure:"exclude_mount_points"`
+	IncludeDevices     []string `mapstructure:"include_devices"`
+	ExcludeDevices     []string `mapstructure:"exclude_devices"` // 为空时默认排除 loop*、ram*
+}
+
+// NetFilterConfig 网卡过滤规则，语法同 DiskFilterConfig，include 优先于 exclude
+type NetFilterConfig struct {
+	IncludeInterfaces []string `mapstructure:"include_interfaces"`
+	ExcludeInterfaces []string `mapstructure:"exclude_interfaces"` // 为空时默认排除 lo、veth*、docker*
+}
+
+// KmsgConfig 内核日志监听，识别 OOM、进程挂起、文件系统/IO 错误与网卡链路变化
+type KmsgConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"` // 默认 /dev/kmsg，也可指向追加写入的文本文件（如测试时）
+}
+
+// LogTailConfig 日志文件跟踪，按规则统计匹配行数并告警
+type LogTailConfig struct {
+	Enabled bool            `mapstructure:"enabled"`
+	Rules   []LogRuleConfig `mapstructure:"rules"`
+}
+
+// LogRuleConfig 单条日志匹配规则
+type LogRuleConfig struct {
+	Name      string   `mapstructure:"name"`      // 规则名，作为指标的 rule 标签
+	Paths     []string `mapstructure:"paths"`     // 日志文件 glob，如 /var/log/app/*.log
+	Regex     string   `mapstructure:"regex"`     // 匹配行的正则，命名分组作为标签，如 (?P<level>ERROR|FATAL)
+	Threshold float64  `mapstructure:"threshold"` // 每分钟匹配行数告警阈值，0 表示只计数不告警
+	Level     string   `mapstructure:"level"`     // 告警级别 warning/error，默认 warning
+}
+
+// FDConfig 进程 fd 统计与泄漏检测
+type FDConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	GrowthWindow time.Duration `mapstructure:"growth_window"` // 持续增长判定窗口，默认 10m
+	GrowthMin    uint64        `mapstructure:"growth_min"`    // 窗口内最少净增长数，默认 100
+}
+
+// CgroupConfig cgroup v2 采集配置，通过深度与白名单控制指标基数
+type CgroupConfig struct {
+	Enabled   bool     `mapstructure:"enabled"`
+	Root      string   `mapstructure:"root"`      // cgroup v2 挂载点，默认 <sys_root>/fs/cgroup
+	MaxDepth  int      `mapstructure:"max_depth"` // 最大遍历深度，根为 0，默认 2
+	Allowlist []string `mapstructure:"allowlist"` // cgroup 路径 glob，如 /system.slice/*.service；为空时输出全部
 }
 
 type DiagnosticConfig struct {
@@ -26,7 +105,9 @@ type DiagnosticConfig struct {
 type AlertConfig struct {
 	Enabled bool `mapstructure:"enabled"`
 	// CPU阈值
-	CPUThreshold float64 `mapstructure:"cpu_threshold"`
+	CPUThreshold       float64 `mapstructure:"cpu_threshold"`
+	CPUIOWaitThreshold float64 `mapstructure:"cpu_iowait_threshold"` // iowait 占比阈值（百分比）
+	CPUStealThreshold  float64 `mapstructure:"cpu_steal_threshold"`  // steal 占比阈值（百分比）
 	// 内存阈值
 	MemoryThreshold float64 `mapstructure:"memory_threshold"`
 	// 硬盘阈值
@@ -34,6 +115,12 @@ type AlertConfig struct {
 	DiskAwaitThreshold float64 `mapstructure:"disk_await_threshold"`
 	DiskUtilThreshold  float64 `mapstructure:"disk_util_threshold"`
 	InodesThreshold    float64 `mapstructure:"inodes_threshold"`
+	// NFS 客户端阈值，按挂载点 + RPC 操作判断
+	NFSRetransThreshold float64 `mapstructure:"nfs_retrans_threshold"` // RPC 重传次数/秒
+	NFSLatencyThreshold float64 `mapstructure:"nfs_latency_threshold"` // RPC 平均执行时间（毫秒）
+	// 内存活动阈值（次/秒），比使用率更能反映内存紧张
+	SwapInRateThreshold     float64 `mapstructure:"swap_in_rate_threshold"`     // swap 换入页数/秒
+	MajorFaultRateThreshold float64 `mapstructure:"major_fault_rate_threshold"` // 主缺页次数/秒
 	// 网络阈值
 	NetworkBandwidthThreshold  float64 `mapstructure:"network_bandwidth_threshold"`   // 网卡带宽使用率阈值（百分比）
 	NetworkPacketLossThreshold float64 `mapstructure:"network_packet_loss_threshold"` // 丢包率阈值（百分比）
@@ -41,6 +128,18 @@ type AlertConfig struct {
 	TCPTimeWaitThreshold       uint64  `mapstructure:"tcp_time_wait_threshold"`       // TIME_WAIT连接数阈值
 	TCPCLOSEWaitThreshold      uint64  `mapstructure:"tcp_close_wait_threshold"`      // CLOSE_WAIT连接数阈值
 	TotalTCPThreshold          uint64  `mapstructure:"total_tcp_threshold"`           // 总TCP连接数阈值
+	TCPRetransRatioThreshold   float64 `mapstructure:"tcp_retrans_ratio_threshold"`   // TCP 重传率阈值（百分比）
+	TCPListenDropsThreshold    float64 `mapstructure:"tcp_listen_drops_threshold"`    // 监听队列丢弃速率阈值（次/秒）
+	// PSI 阈值（avg60，百分比）
+	PSICPUSomeThreshold    float64 `mapstructure:"psi_cpu_some_threshold"`
+	PSIMemorySomeThreshold float64 `mapstructure:"psi_memory_some_threshold"`
+	PSIMemoryFullThreshold float64 `mapstructure:"psi_memory_full_threshold"`
+	PSIIOSomeThreshold     float64 `mapstructure:"psi_io_some_threshold"`
+	PSIIOFullThreshold     float64 `mapstructure:"psi_io_full_threshold"`
+	// 系统资源上限使用率阈值（百分比）
+	FileHandlesThreshold float64 `mapstructure:"file_handles_threshold"` // 文件句柄占 file-max
+	PIDThreshold         float64 `mapstructure:"pid_threshold"`          // 线程数占 pid_max 或 threads-max 中较高者
+	ProcessFDThreshold   float64 `mapstructure:"process_fd_threshold"`   // 单进程 fd 占 RLIMIT_NOFILE 软限制
 }
 
 type EmailConfig struct {
@@ -58,6 +157,7 @@ type HTTPConfig struct {
 }
 
 type PrometheusConfig struct {
-	Enabled bool   `mapstructure:"enabled"`
-	Path    string `mapstructure:"path"`
+	Enabled     bool   `mapstructure:"enabled"`
+	Path        string `mapstructure:"path"`
+	TextfileDir string `mapstructure:"textfile_dir"` // 外部程序写入 *.prom 文件的目录，为空时不读取
 }
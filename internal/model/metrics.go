@@ -1,25 +1,112 @@
 package model
 
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
 // Metrics 系统核心指标
 type Metrics struct {
-	CPU             CPUStat    `json:"cpu"`
-	Mem             MemoryStat `json:"memory"`
-	Disk            []DiskStat `json:"disk"`
-	Net             []NetStat  `json:"net"`
-	Procs           []ProcStat `json:"procs"` // 进程信息
-	Host            string     `json:"host"`
-	UpdateTimestamp string     `json:"update_timestamp"`
+	CPU             CPUStat        `json:"cpu"`
+	CPUFreq         []CPUFreqStat  `json:"cpu_freq"`
+	IRQ             IRQStat        `json:"irq"`
+	Mem             MemoryStat     `json:"memory"`
+	VM              VMStat         `json:"vmstat"`
+	Disk            []DiskStat     `json:"disk"`
+	Net             []NetStat      `json:"net"`
+	TCP             TCPStat        `json:"tcp"`
+	Proto           ProtoStat      `json:"proto"`
+	Limits          LimitsStat     `json:"limits"`
+	Sensors         []SensorStat   `json:"sensors"`
+	Kernel          KernelLogStat  `json:"kernel_log"`
+	Logs            []LogMatchStat `json:"logs"`
+	LogFiles        []LogFileStat  `json:"log_files"`
+	MD              []MDStat       `json:"md"`
+	NFS             []NFSMountStat `json:"nfs"`
+	PSI             PSIStat        `json:"psi"`
+	Cgroups         []CgroupStat   `json:"cgroups"`
+	Procs           []ProcStat     `json:"procs"`        // CPU 占用最高的 TopN 进程
+	ProcsByMem      []ProcStat     `json:"procs_by_mem"` // 内存占用最高的 TopN 进程
+	ProcsByFD       []ProcStat     `json:"procs_by_fd"`  // fd 数或 fd 占用率最高的 TopN 进程，以及 fd 持续增长的进程
+	Info            HostInfo       `json:"host_info"`
+	Host            string         `json:"host"`
+	UpdateTimestamp string         `json:"update_timestamp"`
 }
 
 type CPUStat struct {
-	Cores        int       `json:"cores"`         // CPU核心数
-	UsagePercent float64   `json:"usage_percent"` // CPU使用率，百分制
-	PerCPUUsage  []float64 `json:"per_cpu_usage"` // 单个CPU的使用率
-	Load1        float64   `json:"load1"`         // 1分钟的平均负载
-	Load5        float64   `json:"load5"`         // 5分钟平均负载
-	Load15       float64   `json:"load15"`        // 15分钟平均负载
-	TotalTicks   uint64    `json:"total_ticks"`
-	IdleTicks    uint64    `json:"idle_ticks"`
+	Cores        int        `json:"cores"`         // CPU核心数
+	UsagePercent float64    `json:"usage_percent"` // CPU使用率，百分制
+	PerCPUUsage  []float64  `json:"per_cpu_usage"` // 单个CPU的使用率
+	Modes        CPUModes   `json:"modes"`         // 整体各模式时间占比
+	PerCPUModes  []CPUModes `json:"per_cpu_modes"` // 单个CPU各模式时间占比
+	Load1        float64    `json:"load1"`         // 1分钟的平均负载
+	Load5        float64    `json:"load5"`         // 5分钟平均负载
+	Load15       float64    `json:"load15"`        // 15分钟平均负载
+	TotalTicks   uint64     `json:"total_ticks"`
+	IdleTicks    uint64     `json:"idle_ticks"`
+}
+
+// CPUModes 各 CPU 模式的时间占比（百分制），对应 /proc/stat 的各列
+type CPUModes struct {
+	User    float64 `json:"user"`
+	Nice    float64 `json:"nice"`
+	System  float64 `json:"system"`
+	Idle    float64 `json:"idle"`
+	IOWait  float64 `json:"iowait"`
+	IRQ     float64 `json:"irq"`
+	SoftIRQ float64 `json:"softirq"`
+	Steal   float64 `json:"steal"` // 虚拟机被宿主机其他租户抢占的时间
+}
+
+// HostInfo 主机清单信息，用于将告警与内核版本、重启等事件关联
+type HostInfo struct {
+	KernelRelease  string  `json:"kernel_release"` // uname -r
+	KernelVersion  string  `json:"kernel_version"` // uname -v
+	Arch           string  `json:"arch"`           // uname -m
+	OSID           string  `json:"os_id"`          // os-release ID，如 ubuntu/centos
+	OSVersion      string  `json:"os_version"`     // os-release VERSION_ID
+	OSName         string  `json:"os_name"`        // os-release PRETTY_NAME
+	MachineID      string  `json:"machine_id"`     // /etc/machine-id
+	BootID         string  `json:"boot_id"`        // 每次启动随机生成，用于识别重启
+	BootTime       int64   `json:"boot_time"`      // 启动时间 (Unix 秒)
+	UptimeSeconds  float64 `json:"uptime_seconds"` // 已运行时长
+	CPUModel       string  `json:"cpu_model"`      // CPU 型号
+	CPUCount       int     `json:"cpu_count"`      // 逻辑 CPU 数
+	MemTotal       uint64  `json:"mem_total"`      // 物理内存 (Bytes)
+	Virtualization string  `json:"virtualization"` // kvm/vmware/xen 等，物理机为 none，无法识别为 unknown
+	Container      string  `json:"container"`      // docker/kubernetes 等，非容器为空
+}
+
+// CPUFreqStat 单个 CPU 的频率与降频计数，来自 /sys/devices/system/cpu/cpuN
+type CPUFreqStat struct {
+	CPU                  string `json:"cpu"`                    // CPU 编号，如 "0"
+	CurHz                uint64 `json:"cur_hz"`                 // 当前频率
+	MinHz                uint64 `json:"min_hz"`                 // 调频下限
+	MaxHz                uint64 `json:"max_hz"`                 // 调频上限
+	Governor             string `json:"governor"`               // 调频策略，如 performance/powersave
+	CoreThrottleCount    uint64 `json:"core_throttle_count"`    // 核心过热降频次数
+	PackageThrottleCount uint64 `json:"package_throttle_count"` // 封装过热降频次数
+}
+
+// IRQStat 逐 CPU 的软中断与硬中断计数，PerCPU 与 CPUs 按下标对应
+type IRQStat struct {
+	CPUs       []string        `json:"cpus"`       // 在线 CPU 编号
+	SoftIRQs   []SoftIRQStat   `json:"softirqs"`   // /proc/softirqs
+	Interrupts []InterruptStat `json:"interrupts"` // /proc/interrupts 中计数非 0 的中断
+}
+
+type SoftIRQStat struct {
+	Type   string   `json:"type"` // NET_RX/TIMER/BLOCK 等
+	PerCPU []uint64 `json:"per_cpu"`
+	Total  uint64   `json:"total"`
+}
+
+type InterruptStat struct {
+	IRQ    string   `json:"irq"`    // 中断号或 NMI/LOC 等名称
+	Device string   `json:"device"` // 中断控制器与设备描述，如 "PCI-MSI 524288-edge eth0-TxRx-0"
+	PerCPU []uint64 `json:"per_cpu"`
+	Total  uint64   `json:"total"`
 }
 
 type MemoryStat struct {
@@ -32,11 +119,62 @@ type MemoryStat struct {
 	SwapFree        uint64  `json:"swap_free"`         // Swap空闲 (Bytes)
 	SwapUsed        uint64  `json:"swap_used"`         // Swap已用 (Bytes)
 	SwapUsedPercent float64 `json:"swap_used_percent"` // Swap使用率 (百分制)
+
+	Buffers        uint64 `json:"buffers"`         // 块设备缓冲 (Bytes)
+	Cached         uint64 `json:"cached"`          // 页缓存 (Bytes)
+	Dirty          uint64 `json:"dirty"`           // 等待回写的脏页 (Bytes)
+	Writeback      uint64 `json:"writeback"`       // 正在回写的页 (Bytes)
+	Mapped         uint64 `json:"mapped"`          // 被 mmap 映射的页 (Bytes)
+	Shmem          uint64 `json:"shmem"`           // 共享内存与 tmpfs (Bytes)
+	Slab           uint64 `json:"slab"`            // 内核 slab 总量 (Bytes)
+	SReclaimable   uint64 `json:"sreclaimable"`    // 可回收 slab，如 dentry/inode 缓存 (Bytes)
+	SUnreclaim     uint64 `json:"sunreclaim"`      // 不可回收 slab (Bytes)
+	PageTables     uint64 `json:"page_tables"`     // 页表 (Bytes)
+	CommitLimit    uint64 `json:"commit_limit"`    // 可提交内存上限 (Bytes)
+	CommittedAS    uint64 `json:"committed_as"`    // 已承诺分配的内存 (Bytes)
+	AnonHugePages  uint64 `json:"anon_huge_pages"` // 透明大页 (Bytes)
+	HugePagesTotal uint64 `json:"hugepages_total"` // 预留大页数
+	HugePagesFree  uint64 `json:"hugepages_free"`  // 空闲大页数
+	HugePagesRsvd  uint64 `json:"hugepages_rsvd"`  // 已预订未分配的大页数
+	HugePagesSurp  uint64 `json:"hugepages_surp"`  // 超出预留的大页数
+	HugePageSize   uint64 `json:"hugepage_size"`   // 大页大小 (Bytes)
+}
+
+// VMStat /proc/vmstat 中的缺页、换页、回收与 OOM 计数器
+type VMStat struct {
+	PgFault       uint64 `json:"pgfault"`        // 缺页总数（含次缺页）
+	PgMajFault    uint64 `json:"pgmajfault"`     // 主缺页，需要从磁盘读入
+	PswpIn        uint64 `json:"pswpin"`         // 从 swap 换入的页数
+	PswpOut       uint64 `json:"pswpout"`        // 换出到 swap 的页数
+	PgScanKswapd  uint64 `json:"pgscan_kswapd"`  // kswapd 后台回收扫描页数
+	PgScanDirect  uint64 `json:"pgscan_direct"`  // 直接回收扫描页数，非 0 说明分配路径被阻塞
+	PgStealKswapd uint64 `json:"pgsteal_kswapd"` // kswapd 回收成功页数
+	PgStealDirect uint64 `json:"pgsteal_direct"` // 直接回收成功页数
+	OOMKill       uint64 `json:"oom_kill"`       // OOM killer 触发次数（4.13+）
+
+	Rates VMStatRates `json:"rates"` // 每秒速率，由 engine 基于上一轮计算
+}
+
+type VMStatRates struct {
+	PgFault    float64 `json:"pgfault"`
+	PgMajFault float64 `json:"pgmajfault"`
+	PswpIn     float64 `json:"pswpin"`
+	PswpOut    float64 `json:"pswpout"`
+	PgScan     float64 `json:"pgscan"`  // kswapd + direct
+	PgSteal    float64 `json:"pgsteal"` // kswapd + direct
+	OOMKill    float64 `json:"oom_kill"`
 }
 
 type DiskStat struct {
 	MountPoint        string  `json:"mount_point"`
-	Device            string  `json:"device"`
+	Source            string  `json:"source"`      // 挂载源，如 /dev/mapper/vg-lv、server:/export
+	FSType            string  `json:"fs_type"`     // 文件系统类型
+	FSRoot            string  `json:"fs_root"`     // 挂载的源文件系统目录，bind 挂载或 btrfs 子卷时不为 /
+	Device            string  `json:"device"`      // IO 统计所用的内核设备名，如 sda1、dm-0
+	Partition         string  `json:"partition"`   // 设备为分区时的分区名
+	ParentDisk        string  `json:"parent_disk"` // 所在物理整盘，多块盘时以逗号分隔
+	DMName            string  `json:"dm_name"`     // device-mapper 名称
+	Status            string  `json:"status"`      // 挂载点状态：ok/stale
 	Total             uint64  `json:"total"`
 	Used              uint64  `json:"used"`
 	Free              uint64  `json:"free"`
@@ -45,17 +183,34 @@ type DiskStat struct {
 	InodesUsed        uint64  `json:"inodes_used"`
 	InodesFree        uint64  `json:"inodes_free"`
 	InodesUsedPercent float64 `json:"inodes_used_percent"`
-	Read              uint64  `json:"read"`
+	Read              uint64  `json:"read"`     // 累计读取字节数
+	ReadIOs           uint64  `json:"read_ios"` // 累计读完成次数
 	ReadSectors       uint64  `json:"read_sectors"`
-	ReadSpeed         float64 `json:"read_speed"`
-	Write             uint64  `json:"write"`
+	ReadSpeed         float64 `json:"read_speed"` // 读取速率 (Bytes/s)
+	Write             uint64  `json:"write"`      // 累计写入字节数
+	WriteIOs          uint64  `json:"write_ios"`  // 累计写完成次数
 	WriteSectors      uint64  `json:"write_sectors"`
-	WriteSpeed        float64 `json:"write_speed"`
-	Await             float64 `json:"await"`
-	Util              float64 `json:"util"`
-	IOQueueTime       uint64  `json:"io_queue_time"`
+	WriteSpeed        float64 `json:"write_speed"` // 写入速率 (Bytes/s)
+	// 以下为两次采集之间的 iostat -x 等价指标
+	ReadIOPS     float64 `json:"read_iops"`      // r/s
+	WriteIOPS    float64 `json:"write_iops"`     // w/s
+	ReadKBps     float64 `json:"read_kbps"`      // rkB/s
+	WriteKBps    float64 `json:"write_kbps"`     // wkB/s
+	ReadAwait    float64 `json:"read_await"`     // r_await (ms)
+	WriteAwait   float64 `json:"write_await"`    // w_await (ms)
+	Await        float64 `json:"await"`          // 读写平均耗时 (ms)
+	AvgQueueSize float64 `json:"avg_queue_size"` // aqu-sz
+	Util         float64 `json:"util"`           // %util，基于 io_ticks
+	IOTicks      uint64  `json:"io_ticks"`       // 累计设备忙碌时间 (ms)
+	IOQueueTime  uint64  `json:"io_queue_time"`  // 累计加权 I/O 耗时 (ms)
 }
 
+// 挂载点状态
+const (
+	DiskStatusOK    = "ok"
+	DiskStatusStale = "stale" // statfs 超时未返回，挂载点无响应
+)
+
 type NetStat struct {
 	Name      string  `json:"name"`       // 网卡名
 	RxBytes   uint64  `json:"rx_bytes"`   // 累计接收字节数
@@ -66,13 +221,252 @@ type NetStat struct {
 	TxPackets uint64  `json:"tx_packets"` // 累计发送数据包数
 	TxErrors  uint64  `json:"tx_errors"`  // 累计发送数据包错误数
 	TxDropped uint64  `json:"tx_dropped"` // 累计发送数据包丢弃数
-	RxSpeed   float64 `json:"rx_speed"`
-	TxSpeed   float64 `json:"tx_speed"`
+	RxSpeed   float64 `json:"rx_speed"`   // 接收速率 (Bytes/s)
+	TxSpeed   float64 `json:"tx_speed"`   // 发送速率 (Bytes/s)
+
+	OperState     string  `json:"operstate"`      // 链路状态：up/down/unknown 等
+	Carrier       bool    `json:"carrier"`        // 是否检测到物理链路
+	SpeedMbps     uint64  `json:"speed_mbps"`     // 协商速率 (Mb/s)，虚拟网卡或未知时为 0
+	Duplex        string  `json:"duplex"`         // full/half/unknown
+	MTU           uint64  `json:"mtu"`            // 最大传输单元
+	Type          string  `json:"type"`           // 链路类型：ether/loopback/none 等
+	Virtual       bool    `json:"virtual"`        // 无底层总线设备的虚拟网卡（bridge/bond/tun 等）
+	RxUtilization float64 `json:"rx_utilization"` // 接收带宽使用率（占协商速率百分比）
+	TxUtilization float64 `json:"tx_utilization"` // 发送带宽使用率（占协商速率百分比）
+}
+
+type TCPStat struct {
+	States          map[string]uint64 `json:"states"`            // 各状态连接数，键为 ESTABLISHED/TIME_WAIT/CLOSE_WAIT 等
+	Total           uint64            `json:"total"`             // IPv4 + IPv6 连接总数
+	SocketsUsed     uint64            `json:"sockets_used"`      // 系统已用套接字总数
+	InUse           uint64            `json:"inuse"`             // 正在使用的 TCP 套接字
+	Orphan          uint64            `json:"orphan"`            // 孤儿套接字（已不属于任何进程）
+	TimeWaitSockets uint64            `json:"time_wait_sockets"` // sockstat 中的 tw 计数
+	Alloc           uint64            `json:"alloc"`             // 已分配的 TCP 套接字
+	MemBytes        uint64            `json:"mem_bytes"`         // TCP 缓冲区占用内存 (Bytes)
+}
+
+// ProtoStat 内核协议栈计数器，来自 /proc/net/snmp 与 /proc/net/netstat
+type ProtoStat struct {
+	Counters        map[string]uint64  `json:"counters"`          // 累计值，键为 "协议.字段"，如 Tcp.RetransSegs、TcpExt.ListenDrops
+	Rates           map[string]float64 `json:"rates"`             // 每秒速率，由 engine 基于上一轮计算，首轮为空
+	TCPRetransRatio float64            `json:"tcp_retrans_ratio"` // 采集间隔内重传报文占发送报文的百分比
+}
+
+// LimitsStat 系统级资源上限与用量，来自 /proc/sys/fs、/proc/sys/kernel 与 /proc/loadavg
+type LimitsStat struct {
+	FileHandlesAllocated   uint64  `json:"file_handles_allocated"`    // 已分配文件句柄数
+	FileHandlesMax         uint64  `json:"file_handles_max"`          // fs.file-max
+	FileHandlesUsedPercent float64 `json:"file_handles_used_percent"` // 句柄使用率 (百分制)
+	InodesAllocated        uint64  `json:"inodes_allocated"`          // 内核 inode 表中已分配的 inode 对象数
+	InodesFree             uint64  `json:"inodes_free"`               // 其中空闲的 inode 对象数
+	PIDMax                 uint64  `json:"pid_max"`                   // kernel.pid_max
+	ThreadsMax             uint64  `json:"threads_max"`               // kernel.threads-max
+	TasksRunning           uint64  `json:"tasks_running"`             // 可运行的调度实体数
+	TasksTotal             uint64  `json:"tasks_total"`               // 调度实体（线程）总数
+	PIDUsedPercent         float64 `json:"pid_used_percent"`          // 线程总数占 pid_max 的百分比
+	ThreadsUsedPercent     float64 `json:"threads_used_percent"`      // 线程总数占 threads-max 的百分比
+}
+
+// NFSMountStat 单个 NFS 挂载的客户端统计，来自 /proc/[pid]/mountstats
+type NFSMountStat struct {
+	Export     string      `json:"export"`      // server:/export
+	MountPoint string      `json:"mount_point"` // 挂载点
+	FSType     string      `json:"fs_type"`     // nfs/nfs4
+	AgeSeconds uint64      `json:"age_seconds"` // 挂载时长
+	Ops        []NFSOpStat `json:"ops"`         // 发生过调用的 RPC 操作
+}
+
+// NFSOpStat 单个 RPC 操作的累计统计，时间单位均为毫秒
+type NFSOpStat struct {
+	Op              string `json:"op"`              // READ/WRITE/GETATTR 等
+	Ops             uint64 `json:"ops"`             // 请求数
+	Transmissions   uint64 `json:"transmissions"`   // 发送次数，含重传
+	Retransmissions uint64 `json:"retransmissions"` // 重传次数
+	MajorTimeouts   uint64 `json:"major_timeouts"`  // 主超时次数
+	BytesSent       uint64 `json:"bytes_sent"`
+	BytesRecv       uint64 `json:"bytes_recv"`
+	QueueMs         uint64 `json:"queue_ms"`   // 累计排队时间
+	RTTMs           uint64 `json:"rtt_ms"`     // 累计往返时间
+	ExecuteMs       uint64 `json:"execute_ms"` // 累计执行时间（从排队到收到回复）
+	Errors          uint64 `json:"errors"`     // 返回错误的请求数，旧内核不提供
+
+	// 以下由 engine 基于上一轮计算，首轮为 0
+	OpsRate     float64 `json:"ops_rate"`     // 请求数/秒
+	RetransRate float64 `json:"retrans_rate"` // 重传次数/秒
+	AvgRTTMs    float64 `json:"avg_rtt_ms"`   // 采集间隔内平均 RTT
+	AvgExecMs   float64 `json:"avg_exec_ms"`  // 采集间隔内平均执行时间
+}
+
+// 软 RAID 成员状态
+const (
+	MDMemberActive = "active"
+	MDMemberFailed = "failed"
+	MDMemberSpare  = "spare"
+)
+
+// MDStat /proc/mdstat 中的软 RAID 阵列
+type MDStat struct {
+	Name              string     `json:"name"`                // 阵列名，如 md0
+	State             string     `json:"state"`               // active/inactive，只读时带 (read-only) 等后缀
+	Level             string     `json:"level"`               // raid1/raid5 等，inactive 阵列为空
+	Members           []MDMember `json:"members"`             // 成员设备
+	Blocks            uint64     `json:"blocks"`              // 阵列容量 (1K blocks)
+	DisksTotal        int        `json:"disks_total"`         // 期望成员数，raid0/linear 没有该信息时为 0
+	DisksActive       int        `json:"disks_active"`        // 在线成员数
+	Failed            int        `json:"failed"`              // 故障成员数
+	Spare             int        `json:"spare"`               // 热备成员数
+	Degraded          bool       `json:"degraded"`            // 存在故障成员或在线成员不足
+	SyncAction        string     `json:"sync_action"`         // resync/recovery/reshape/check/repair，排队中带 delayed/pending 后缀
+	SyncPercent       float64    `json:"sync_percent"`        // 同步进度 (百分制)
+	SyncFinishSeconds float64    `json:"sync_finish_seconds"` // 预计剩余时间 (秒)
+	SyncSpeedKBps     float64    `json:"sync_speed_kbps"`     // 同步速度 (KB/s)
+}
+
+type MDMember struct {
+	Name  string `json:"name"`  // 成员设备名，如 sda1
+	State string `json:"state"` // active/failed/spare
+}
+
+// 传感器类型
+const (
+	SensorTemperature = "temperature"
+	SensorFan         = "fan"
+)
+
+// SensorStat 硬件传感器读数，来自 hwmon 或 thermal zone；阈值为 0 表示内核未提供
+type SensorStat struct {
+	Chip   string  `json:"chip"`   // hwmon 芯片名（如 coretemp、nvme）或温区类型（如 x86_pkg_temp）
+	Device string  `json:"device"` // sysfs 设备名，如 hwmon1、thermal_zone0
+	Sensor string  `json:"sensor"` // 传感器通道，如 temp1、fan2
+	Label  string  `json:"label"`  // *_label 中的描述，如 "Package id 0"
+	Type   string  `json:"type"`   // temperature/fan
+	Value  float64 `json:"value"`  // 温度 (°C) 或转速 (RPM)
+	Min    float64 `json:"min"`    // 最低阈值，风扇低于该转速视为故障
+	Max    float64 `json:"max"`    // 最高阈值
+	Crit   float64 `json:"crit"`   // 内核报告的临界阈值
+	Alarm  bool    `json:"alarm"`  // 硬件告警位
+}
+
+// 内核日志事件类型
+const (
+	KernelEventOOMKill  = "oom_kill"
+	KernelEventHungTask = "hung_task"
+	KernelEventFSError  = "fs_error"
+	KernelEventIOError  = "io_error"
+	KernelEventLinkDown = "link_down"
+	KernelEventLinkUp   = "link_up"
+)
+
+// KernelEvent 从内核日志（/dev/kmsg）中识别出的事件
+type KernelEvent struct {
+	Type      string    `json:"type"`              // oom_kill/hung_task/fs_error/io_error/link_down/link_up
+	Process   string    `json:"process,omitempty"` // OOM 被杀或挂起的进程名
+	PID       int       `json:"pid,omitempty"`
+	Device    string    `json:"device,omitempty"` // 出错的块设备或网卡
+	Message   string    `json:"message"`          // 原始日志
+	Timestamp time.Time `json:"timestamp"`        // 读取到日志的时间
+}
+
+// KernelLogStat 内核日志事件统计，计数从采集器启动开始累计
+type KernelLogStat struct {
+	Active bool              `json:"active"` // 监听正常运行，OOM 等事件由内核日志即时告警
+	Events map[string]uint64 `json:"events"` // 按类型累计的事件数
+	Recent []KernelEvent     `json:"recent"` // 最近的若干事件
+}
+
+// LogMatchStat 日志规则按标签统计的匹配行数，计数从首次跟踪文件开始累计
+type LogMatchStat struct {
+	Rule      string            `json:"rule"`
+	Labels    map[string]string `json:"labels,omitempty"` // 正则命名分组的取值
+	Count     uint64            `json:"count"`            // 累计匹配行数
+	Rate      float64           `json:"rate"`             // 每分钟匹配行数，由 engine 计算
+	Threshold float64           `json:"threshold"`        // 规则配置的告警阈值
+	Level     string            `json:"level"`            // 规则配置的告警级别
+	LastLine  string            `json:"last_line"`        // 最近一次匹配的行，告警时附带
+}
+
+// SeriesKey 规则名加排序后的标签，用于跨周期匹配同一计数序列
+func (l LogMatchStat) SeriesKey() string {
+	names := make([]string, 0, len(l.Labels))
+	for name := range l.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString(l.Rule)
+	for _, name := range names {
+		b.WriteString("\x00" + name + "=" + l.Labels[name])
+	}
+	return b.String()
+}
+
+// LogFileStat 正在跟踪的日志文件
+type LogFileStat struct {
+	Path   string `json:"path"`
+	Size   uint64 `json:"size"`   // 文件大小 (Bytes)
+	Offset uint64 `json:"offset"` // 已读取到的位置，落后于 Size 说明本轮未读完
+}
+
+// PSIStat Pressure Stall Information，反映 CPU/内存/IO 资源争抢导致的任务停顿
+type PSIStat struct {
+	Available bool        `json:"available"` // 内核是否支持 PSI
+	CPU       PSIResource `json:"cpu"`
+	Memory    PSIResource `json:"memory"`
+	IO        PSIResource `json:"io"`
+}
+
+type PSIResource struct {
+	Some PSILine `json:"some"` // 至少有一个任务因该资源停顿
+	Full PSILine `json:"full"` // 所有非空闲任务同时停顿
+}
+
+type PSILine struct {
+	Avg10  float64 `json:"avg10"`  // 近10秒停顿时间占比（百分制）
+	Avg60  float64 `json:"avg60"`  // 近60秒停顿时间占比（百分制）
+	Avg300 float64 `json:"avg300"` // 近300秒停顿时间占比（百分制）
+	Total  uint64  `json:"total"`  // 累计停顿时间（微秒）
+}
+
+// CgroupStat 单个 cgroup v2 的资源用量，Path 为相对 cgroup 根的路径，如 /system.slice/docker.service
+type CgroupStat struct {
+	Path              string  `json:"path"`
+	CPUUsageUsec      uint64  `json:"cpu_usage_usec"`      // 累计 CPU 时间（微秒）
+	CPUUserUsec       uint64  `json:"cpu_user_usec"`       // 累计用户态 CPU 时间（微秒）
+	CPUSystemUsec     uint64  `json:"cpu_system_usec"`     // 累计内核态 CPU 时间（微秒）
+	CPUUsagePercent   float64 `json:"cpu_usage_percent"`   // 两次采集间的 CPU 使用率（百分制，多核可超过100）
+	NrThrottled       uint64  `json:"nr_throttled"`        // 被 CPU 配额限流的周期数
+	ThrottledUsec     uint64  `json:"throttled_usec"`      // 累计被限流时间（微秒）
+	MemoryCurrent     uint64  `json:"memory_current"`      // 当前内存用量 (Bytes)
+	MemoryMax         uint64  `json:"memory_max"`          // 内存上限 (Bytes)，0 表示不限制
+	MemoryUsedPercent float64 `json:"memory_used_percent"` // 内存用量占上限百分比
+	OOMEvents         uint64  `json:"oom_events"`          // 触达内存上限的次数
+	OOMKills          uint64  `json:"oom_kills"`           // 被 OOM Killer 杀死的进程数
+	IOReadBytes       uint64  `json:"io_read_bytes"`
+	IOWriteBytes      uint64  `json:"io_write_bytes"`
+	IOReadOps         uint64  `json:"io_read_ops"`
+	IOWriteOps        uint64  `json:"io_write_ops"`
+	PidsCurrent       uint64  `json:"pids_current"` // 当前进程/线程数
 }
 
 type ProcStat struct {
-	PID  int     `json:"pid"`
-	Name string  `json:"name"`
-	CPU  float64 `json:"cpu"`
-	Mem  float64 `json:"mem"`
+	PID     int     `json:"pid"`
+	PPID    int     `json:"ppid"`
+	Name    string  `json:"name"`    // 进程名（/proc/[pid]/stat 中的 comm）
+	Cmdline string  `json:"cmdline"` // 完整命令行
+	State   string  `json:"state"`   // 进程状态：R/S/D/Z/T 等
+	User    string  `json:"user"`    // 进程属主
+	Threads int     `json:"threads"` // 线程数
+	CPU     float64 `json:"cpu"`     // 两次采集间的 CPU 使用率（百分制，多核可超过100）
+	Mem     float64 `json:"mem"`     // RSS 占总内存的百分比
+	RSS     uint64  `json:"rss"`     // 常驻内存 (Bytes)
+
+	FDs           uint64  `json:"fds"`             // 打开的文件描述符数，无权限读取时为 0
+	Sockets       uint64  `json:"sockets"`         // 其中 socket 数，仅 procs_by_fd 中统计
+	FDSoftLimit   uint64  `json:"fd_soft_limit"`   // RLIMIT_NOFILE 软限制，unlimited 为 0
+	FDHardLimit   uint64  `json:"fd_hard_limit"`   // RLIMIT_NOFILE 硬限制
+	FDUsedPercent float64 `json:"fd_used_percent"` // fd 数占软限制的百分比
+	FDGrowth      int64   `json:"fd_growth"`       // 增长窗口内 fd 数的变化量
+	FDGrowing     bool    `json:"fd_growing"`      // 整个增长窗口内 fd 数持续增长，疑似泄漏
+	FDGrowthStart bool    `json:"fd_growth_start"` // 本轮刚进入持续增长状态，告警只在此时触发一次
 }